@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -12,6 +15,8 @@ import (
 	"github.com/your-org/n8n-clone/internal/api/handlers"
 	"github.com/your-org/n8n-clone/internal/api/middleware"
 	"github.com/your-org/n8n-clone/internal/service"
+	"github.com/your-org/n8n-clone/internal/storage"
+	"github.com/your-org/n8n-clone/internal/trigger"
 
 	_ "github.com/lib/pq"
 )
@@ -62,10 +67,64 @@ func main() {
 	// Initialize services
 	workflowSvc := service.NewWorkflowService(db)
 	executionSvc := service.NewExecutionService(db, temporalClient)
+	credentialSvc := service.NewCredentialService(db)
+	protoDescriptorSvc := service.NewProtoDescriptorService(db)
+
+	// Start trigger listeners (workflows started by external events)
+	triggerInterval := 30 * time.Second
+	if v := os.Getenv("TRIGGER_SYNC_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			triggerInterval = d
+		} else {
+			log.Printf("Invalid TRIGGER_SYNC_INTERVAL %q, using %s", v, triggerInterval)
+		}
+	}
+	triggerCtx, stopTriggers := context.WithCancel(context.Background())
+	defer stopTriggers()
+	triggerSources := []trigger.Source{
+		trigger.NewPostgresSource(databaseURL, executionSvc),
+	}
+	// File watch triggers are sandboxed under FILE_WATCH_ROOT and disabled without it
+	if root := os.Getenv("FILE_WATCH_ROOT"); root != "" {
+		pollInterval, _ := time.ParseDuration(os.Getenv("FILE_WATCH_POLL_INTERVAL"))
+		triggerSources = append(triggerSources,
+			trigger.NewFileWatchSource(root, pollInterval, db, storage.NewBinaryStore(db), executionSvc))
+	}
+	// Inbound email is accepted by an embedded SMTP server when SMTP_LISTEN_ADDR is set
+	if smtpAddr := os.Getenv("SMTP_LISTEN_ADDR"); smtpAddr != "" {
+		smtpDomain := os.Getenv("SMTP_DOMAIN")
+		if smtpDomain == "" {
+			smtpDomain = "localhost"
+		}
+		maxMessageBytes := int64(25 << 20)
+		if v, err := strconv.ParseInt(os.Getenv("SMTP_MAX_MESSAGE_BYTES"), 10, 64); err == nil && v > 0 {
+			maxMessageBytes = v
+		}
+		emailSource := trigger.NewEmailSource(smtpAddr, smtpDomain, maxMessageBytes, storage.NewBinaryStore(db), executionSvc)
+		go func() {
+			if err := emailSource.ListenAndServe(); err != nil {
+				log.Printf("SMTP server stopped: %v", err)
+			}
+		}()
+		triggerSources = append(triggerSources, emailSource)
+	}
+	// NATS subscriptions are enabled by NATS_URL
+	if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+		natsSource, err := trigger.NewNATSSource(natsURL, executionSvc)
+		if err != nil {
+			log.Printf("NATS triggers disabled: %v", err)
+		} else {
+			triggerSources = append(triggerSources, natsSource)
+		}
+	}
+	triggerManager := trigger.NewManager(db, triggerInterval, triggerSources...)
+	go triggerManager.Run(triggerCtx)
 
 	// Initialize handlers
 	workflowHandler := &handlers.WorkflowHandler{WorkflowService: workflowSvc}
 	executionHandler := &handlers.ExecutionHandler{ExecutionService: executionSvc}
+	credentialHandler := &handlers.CredentialHandler{CredentialService: credentialSvc}
+	protoDescriptorHandler := &handlers.ProtoDescriptorHandler{ProtoDescriptorService: protoDescriptorSvc}
 
 	// Register routes
 	v1 := router.Group("/api/v1")
@@ -75,6 +134,7 @@ func main() {
 		v1.GET("/workflows/:id", workflowHandler.GetWorkflow)
 		v1.PUT("/workflows/:id", workflowHandler.UpdateWorkflow)
 		v1.GET("/workflows", workflowHandler.ListWorkflows)
+		v1.GET("/workflows/:id/form", workflowHandler.GetWorkflowForm)
 
 		// Workflow version routes
 		v1.GET("/workflows/:id/versions", workflowHandler.ListVersions)
@@ -85,6 +145,19 @@ func main() {
 		v1.POST("/workflows/:id/run", executionHandler.RunWorkflow)
 		v1.GET("/executions/:id", executionHandler.GetExecution)
 		v1.GET("/workflows/:id/executions", executionHandler.ListExecutions)
+
+		// Credential routes
+		v1.POST("/credentials", credentialHandler.CreateCredential)
+		v1.GET("/credentials", credentialHandler.ListCredentials)
+		v1.GET("/credentials/:id", credentialHandler.GetCredential)
+		v1.PUT("/credentials/:id", credentialHandler.UpdateCredential)
+		v1.DELETE("/credentials/:id", credentialHandler.DeleteCredential)
+
+		// Protobuf descriptor sets for grpc nodes
+		v1.POST("/proto-descriptors", protoDescriptorHandler.CreateProtoDescriptor)
+		v1.GET("/proto-descriptors", protoDescriptorHandler.ListProtoDescriptors)
+		v1.GET("/proto-descriptors/:id", protoDescriptorHandler.GetProtoDescriptor)
+		v1.DELETE("/proto-descriptors/:id", protoDescriptorHandler.DeleteProtoDescriptor)
 	}
 
 	// Health check endpoint
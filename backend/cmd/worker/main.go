@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/joho/godotenv"
@@ -56,15 +57,41 @@ func main() {
 
 	// Register workflows
 	w.RegisterWorkflow(temporal.DAGWorkflow)
+	w.RegisterWorkflow(temporal.BatchChunkWorkflow)
 
 	// Register activities
-	activities := &temporal.Activities{DB: db}
+	activities := &temporal.Activities{
+		DB:           db,
+		FileRoot:     os.Getenv("FILE_NODE_ROOT"),
+		ExecCommands: splitList(os.Getenv("EXEC_ALLOWED_COMMANDS")),
+		ExecEnv:      splitList(os.Getenv("EXEC_ALLOWED_ENV")),
+	}
 	w.RegisterActivity(activities.HttpRequestActivity)
 	w.RegisterActivity(activities.LoadDAGActivity)
 	w.RegisterActivity(activities.StoreExecutionResultActivity)
 	w.RegisterActivity(activities.UpdateExecutionStatusActivity)
 	w.RegisterActivity(activities.StoreExecutionErrorActivity)
 	w.RegisterActivity(activities.CodeExecutionActivity)
+	w.RegisterActivity(activities.SetFieldsActivity)
+	w.RegisterActivity(activities.FilterActivity)
+	w.RegisterActivity(activities.ItemsActivity)
+	w.RegisterActivity(activities.AggregateActivity)
+	w.RegisterActivity(activities.PostgresActivity)
+	w.RegisterActivity(activities.RedisActivity)
+	w.RegisterActivity(activities.SendEmailActivity)
+	w.RegisterActivity(activities.CSVActivity)
+	w.RegisterActivity(activities.ParseActivity)
+	w.RegisterActivity(activities.TemplateActivity)
+	w.RegisterActivity(activities.DatetimeActivity)
+	w.RegisterActivity(activities.CryptoActivity)
+	w.RegisterActivity(activities.JWTActivity)
+	w.RegisterActivity(activities.GraphQLActivity)
+	w.RegisterActivity(activities.GrpcActivity)
+	w.RegisterActivity(activities.S3Activity)
+	w.RegisterActivity(activities.FileActivity)
+	w.RegisterActivity(activities.CompressActivity)
+	w.RegisterActivity(activities.NATSActivity)
+	w.RegisterActivity(activities.ExecActivity)
 
 	// Start worker
 	log.Println("Starting Temporal worker...")
@@ -83,3 +110,14 @@ func main() {
 	w.Stop()
 	log.Println("Worker stopped")
 }
+
+// splitList parses a comma separated setting, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/your-org/n8n-clone/internal/service"
+)
+
+// CredentialHandler handles credential-related requests
+type CredentialHandler struct {
+	CredentialService *service.CredentialService
+}
+
+// CreateCredential handles POST /credentials
+func (h *CredentialHandler) CreateCredential(c *gin.Context) {
+	var req struct {
+		Name string                 `json:"name" binding:"required"`
+		Type string                 `json:"type" binding:"required"`
+		Data map[string]interface{} `json:"data"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cred, err := h.CredentialService.CreateCredential(c.Request.Context(), req.Name, req.Type, req.Data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, cred)
+}
+
+// GetCredential handles GET /credentials/:id
+func (h *CredentialHandler) GetCredential(c *gin.Context) {
+	cred, err := h.CredentialService.GetCredential(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "credential not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cred)
+}
+
+// ListCredentials handles GET /credentials
+func (h *CredentialHandler) ListCredentials(c *gin.Context) {
+	creds, err := h.CredentialService.ListCredentials(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": creds})
+}
+
+// UpdateCredential handles PUT /credentials/:id
+func (h *CredentialHandler) UpdateCredential(c *gin.Context) {
+	var req struct {
+		Name string                 `json:"name"`
+		Data map[string]interface{} `json:"data"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cred, err := h.CredentialService.UpdateCredential(c.Request.Context(), c.Param("id"), req.Name, req.Data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "credential not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cred)
+}
+
+// DeleteCredential handles DELETE /credentials/:id
+func (h *CredentialHandler) DeleteCredential(c *gin.Context) {
+	if err := h.CredentialService.DeleteCredential(c.Request.Context(), c.Param("id")); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "credential not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -16,10 +19,42 @@ type ExecutionHandler struct {
 }
 
 // RunWorkflow handles POST /workflows/:id/run
+// An optional JSON body is validated against the workflow's input schema and
+// becomes the start node's payload.
 func (h *ExecutionHandler) RunWorkflow(c *gin.Context) {
 	workflowID := c.Param("id")
 
-	execID, err := h.ExecutionService.StartExecution(c.Request.Context(), workflowID)
+	var payload interface{}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON payload: " + err.Error()})
+			return
+		}
+	}
+
+	if err := h.ExecutionService.ValidateInput(c.Request.Context(), workflowID, payload); err != nil {
+		var inputErr *service.InputValidationError
+		if errors.As(err, &inputErr) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":  "input validation failed",
+				"fields": inputErr.Fields,
+			})
+			return
+		}
+		if err == service.ErrWorkflowNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "workflow not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	execID, err := h.ExecutionService.StartExecutionWithPayload(c.Request.Context(), workflowID, payload)
 	if err != nil {
 		if err == service.ErrWorkflowNotFound || err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, gin.H{"error": "workflow not found"})
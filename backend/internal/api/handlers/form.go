@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// formField is one input rendered from a top-level schema property
+type formField struct {
+	Name        string
+	Label       string
+	Description string
+	Type        string // JSON Schema type, drives how the value is serialized
+	Input       string // text, number, checkbox, select, textarea, date, email, ...
+	Options     []string
+	Default     string
+	Checked     bool
+	Required    bool
+}
+
+var workflowFormTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Run {{.Name}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #1f2937; }
+label { display: block; font-weight: 600; margin-top: 1rem; }
+small { display: block; color: #6b7280; }
+input[type=text], input[type=number], input[type=email], input[type=date], input[type=url], select, textarea { width: 100%; padding: .5rem; box-sizing: border-box; }
+textarea { min-height: 6rem; font-family: monospace; }
+.error { color: #b91c1c; font-size: .875rem; }
+button { margin-top: 1.5rem; padding: .6rem 1.2rem; }
+#result { margin-top: 1rem; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+<form id="run-form">
+{{range .Fields}}
+<label for="f-{{.Name}}">{{.Label}}{{if .Required}} *{{end}}</label>
+{{if .Description}}<small>{{.Description}}</small>{{end}}
+{{if eq .Input "select"}}
+<select id="f-{{.Name}}" name="{{.Name}}" data-type="{{.Type}}"{{if .Required}} required{{end}}>
+{{if not .Required}}<option value=""></option>{{end}}
+{{$def := .Default}}{{range .Options}}<option value="{{.}}"{{if eq . $def}} selected{{end}}>{{.}}</option>{{end}}
+</select>
+{{else if eq .Input "checkbox"}}
+<input type="checkbox" id="f-{{.Name}}" name="{{.Name}}" data-type="{{.Type}}"{{if .Checked}} checked{{end}}>
+{{else if eq .Input "textarea"}}
+<textarea id="f-{{.Name}}" name="{{.Name}}" data-type="{{.Type}}"{{if .Required}} required{{end}}>{{.Default}}</textarea>
+{{else}}
+<input type="{{.Input}}" id="f-{{.Name}}" name="{{.Name}}" data-type="{{.Type}}" value="{{.Default}}"{{if eq .Type "number"}} step="any"{{end}}{{if .Required}} required{{end}}>
+{{end}}
+<div class="error" data-error-for="{{.Name}}"></div>
+{{end}}
+<button type="submit">Run workflow</button>
+</form>
+<div id="result"></div>
+<script>
+const form = document.getElementById("run-form");
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  document.querySelectorAll("[data-error-for]").forEach((el) => { el.textContent = ""; });
+  const result = document.getElementById("result");
+  const payload = {};
+  try {
+    for (const el of form.querySelectorAll("[data-type]")) {
+      const type = el.dataset.type;
+      if (type === "boolean") { payload[el.name] = el.checked; continue; }
+      if (el.value === "") continue;
+      if (type === "integer") payload[el.name] = parseInt(el.value, 10);
+      else if (type === "number") payload[el.name] = parseFloat(el.value);
+      else if (type === "object" || type === "array") payload[el.name] = JSON.parse(el.value);
+      else payload[el.name] = el.value;
+    }
+  } catch (err) {
+    result.textContent = "Invalid JSON: " + err.message;
+    return;
+  }
+  const resp = await fetch("run", { method: "POST", headers: { "Content-Type": "application/json" }, body: JSON.stringify(payload) });
+  const body = await resp.json();
+  if (resp.status === 422) {
+    result.textContent = "Please fix the highlighted fields.";
+    for (const f of body.fields || []) {
+      const name = f.field.split("/")[1] || "";
+      const el = document.querySelector('[data-error-for="' + name + '"]');
+      if (el) el.textContent += f.message + " ";
+      else result.textContent += " " + f.field + ": " + f.message;
+    }
+    return;
+  }
+  result.textContent = resp.ok ? "Started execution " + body.execution_id : "Error: " + body.error;
+});
+</script>
+</body>
+</html>
+`))
+
+// GetWorkflowForm handles GET /workflows/:id/form
+// It renders an HTML form generated from the workflow's input schema that
+// submits to the run endpoint.
+func (h *WorkflowHandler) GetWorkflowForm(c *gin.Context) {
+	workflowID := c.Param("id")
+
+	wf, err := h.WorkflowService.GetWorkflow(c.Request.Context(), workflowID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "workflow not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	dagStruct, err := h.WorkflowService.ParseWorkflowDAG(wf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse workflow dag"})
+		return
+	}
+
+	schema, _ := dagStruct.InputSchema.(map[string]interface{})
+	description, _ := schema["description"].(string)
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if err := workflowFormTemplate.Execute(c.Writer, gin.H{
+		"Name":        wf.Name,
+		"Description": description,
+		"Fields":      schemaFormFields(schema),
+	}); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// schemaFormFields flattens the top-level properties of an object schema into
+// form inputs. Fields are ordered by "x-order", then required ones first,
+// then by name, since JSONB storage does not keep key order.
+func schemaFormFields(schema map[string]interface{}) []formField {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, name := range list {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	type orderedField struct {
+		field formField
+		order float64
+	}
+	var ordered []orderedField
+
+	for name, raw := range properties {
+		prop, _ := raw.(map[string]interface{})
+		field := formField{Name: name, Label: name, Required: required[name], Input: "text", Type: "string"}
+
+		if title, ok := prop["title"].(string); ok && title != "" {
+			field.Label = title
+		}
+		field.Description, _ = prop["description"].(string)
+		if t, ok := prop["type"].(string); ok {
+			field.Type = t
+		}
+
+		switch field.Type {
+		case "integer", "number":
+			field.Type = "number"
+			if t, _ := prop["type"].(string); t == "integer" {
+				field.Type = "integer"
+			}
+			field.Input = "number"
+		case "boolean":
+			field.Input = "checkbox"
+			field.Checked, _ = prop["default"].(bool)
+		case "object", "array":
+			field.Input = "textarea"
+		default:
+			switch prop["format"] {
+			case "date":
+				field.Input = "date"
+			case "email":
+				field.Input = "email"
+			case "uri":
+				field.Input = "url"
+			}
+			if prop["x-multiline"] == true {
+				field.Input = "textarea"
+			}
+		}
+
+		if enum, ok := prop["enum"].([]interface{}); ok && len(enum) > 0 {
+			field.Input = "select"
+			for _, v := range enum {
+				field.Options = append(field.Options, formatDefault(v))
+			}
+		}
+		if def, ok := prop["default"]; ok && field.Input != "checkbox" {
+			field.Default = formatDefault(def)
+		}
+
+		order := float64(1 << 20)
+		if o, ok := prop["x-order"].(float64); ok {
+			order = o
+		}
+		ordered = append(ordered, orderedField{field: field, order: order})
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.order != b.order {
+			return a.order < b.order
+		}
+		if a.field.Required != b.field.Required {
+			return a.field.Required
+		}
+		return a.field.Name < b.field.Name
+	})
+
+	fields := make([]formField, len(ordered))
+	for i, f := range ordered {
+		fields[i] = f.field
+	}
+	return fields
+}
+
+func formatDefault(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		b, _ := json.Marshal(val)
+		return string(b)
+	}
+}
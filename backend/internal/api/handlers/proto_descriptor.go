@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/your-org/n8n-clone/internal/service"
+)
+
+// maxDescriptorBytes caps uploaded descriptor sets
+const maxDescriptorBytes = 16 << 20
+
+// ProtoDescriptorHandler handles uploaded protobuf descriptor sets
+type ProtoDescriptorHandler struct {
+	ProtoDescriptorService *service.ProtoDescriptorService
+}
+
+// CreateProtoDescriptor handles POST /proto-descriptors, a multipart form
+// with a name and the descriptor set as file
+func (h *ProtoDescriptorHandler) CreateProtoDescriptor(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxDescriptorBytes+(1<<20))
+	name := c.PostForm("name")
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required: " + err.Error()})
+		return
+	}
+	if header.Size > maxDescriptorBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "descriptor set is too large"})
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	desc, err := h.ProtoDescriptorService.CreateProtoDescriptor(c.Request.Context(), name, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, desc)
+}
+
+// GetProtoDescriptor handles GET /proto-descriptors/:id
+func (h *ProtoDescriptorHandler) GetProtoDescriptor(c *gin.Context) {
+	desc, err := h.ProtoDescriptorService.GetProtoDescriptor(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "descriptor not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, desc)
+}
+
+// ListProtoDescriptors handles GET /proto-descriptors
+func (h *ProtoDescriptorHandler) ListProtoDescriptors(c *gin.Context) {
+	descs, err := h.ProtoDescriptorService.ListProtoDescriptors(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": descs})
+}
+
+// DeleteProtoDescriptor handles DELETE /proto-descriptors/:id
+func (h *ProtoDescriptorHandler) DeleteProtoDescriptor(c *gin.Context) {
+	if err := h.ProtoDescriptorService.DeleteProtoDescriptor(c.Request.Context(), c.Param("id")); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "descriptor not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -19,13 +19,15 @@ type WorkflowHandler struct {
 }
 
 type workflowResponse struct {
-	ID        string        `json:"id"`
-	Name      string        `json:"name"`
-	Nodes     []models.Node `json:"nodes"`
-	Edges     []models.Edge `json:"edges"`
-	Version   *int          `json:"version"`
-	CreatedAt time.Time     `json:"createdAt"`
-	UpdatedAt time.Time     `json:"updatedAt"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Nodes       []models.Node `json:"nodes"`
+	Edges       []models.Edge `json:"edges"`
+	InputSchema interface{}   `json:"inputSchema,omitempty"`
+	Timezone    string        `json:"timezone,omitempty"`
+	Version     *int          `json:"version"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
 }
 
 func (h *WorkflowHandler) toWorkflowResponse(wf *models.Workflow) (*workflowResponse, error) {
@@ -42,22 +44,26 @@ func (h *WorkflowHandler) toWorkflowResponse(wf *models.Workflow) (*workflowResp
 	}
 
 	return &workflowResponse{
-		ID:        wf.ID,
-		Name:      wf.Name,
-		Nodes:     dagStruct.Nodes,
-		Edges:     dagStruct.Edges,
-		Version:   version,
-		CreatedAt: wf.CreatedAt,
-		UpdatedAt: wf.UpdatedAt,
+		ID:          wf.ID,
+		Name:        wf.Name,
+		Nodes:       dagStruct.Nodes,
+		Edges:       dagStruct.Edges,
+		InputSchema: dagStruct.InputSchema,
+		Timezone:    dagStruct.Timezone,
+		Version:     version,
+		CreatedAt:   wf.CreatedAt,
+		UpdatedAt:   wf.UpdatedAt,
 	}, nil
 }
 
 // CreateWorkflow handles POST /workflows
 func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
 	var req struct {
-		Name  string        `json:"name" binding:"required"`
-		Nodes []models.Node `json:"nodes" binding:"required"`
-		Edges []models.Edge `json:"edges" binding:"required"`
+		Name        string        `json:"name" binding:"required"`
+		Nodes       []models.Node `json:"nodes" binding:"required"`
+		Edges       []models.Edge `json:"edges" binding:"required"`
+		InputSchema interface{}   `json:"inputSchema"`
+		Timezone    string        `json:"timezone"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -66,8 +72,10 @@ func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
 	}
 
 	wf, err := h.WorkflowService.CreateWorkflow(c.Request.Context(), req.Name, models.DAGStructure{
-		Nodes: req.Nodes,
-		Edges: req.Edges,
+		Nodes:       req.Nodes,
+		Edges:       req.Edges,
+		InputSchema: req.InputSchema,
+		Timezone:    req.Timezone,
 	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -113,10 +121,14 @@ func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
 func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
 	workflowID := c.Param("id")
 
+	// InputSchema stays raw and Timezone is a pointer so an absent field (keep
+	// the stored value) can be told apart from null or "" (remove it)
 	var req struct {
-		Name  string        `json:"name"`
-		Nodes []models.Node `json:"nodes"`
-		Edges []models.Edge `json:"edges"`
+		Name        string          `json:"name"`
+		Nodes       []models.Node   `json:"nodes"`
+		Edges       []models.Edge   `json:"edges"`
+		InputSchema json.RawMessage `json:"inputSchema"`
+		Timezone    *string         `json:"timezone"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -124,10 +136,40 @@ func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
 		return
 	}
 
+	// Start from the stored DAG and replace only the fields the request sends
 	var dagStruct *models.DAGStructure
-	if req.Nodes != nil || req.Edges != nil {
-		ds := models.DAGStructure{Nodes: req.Nodes, Edges: req.Edges}
-		dagStruct = &ds
+	if req.Nodes != nil || req.Edges != nil || req.InputSchema != nil || req.Timezone != nil {
+		current, err := h.WorkflowService.GetWorkflow(c.Request.Context(), workflowID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, gin.H{"error": "workflow not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		dagStruct, err = h.WorkflowService.ParseWorkflowDAG(current)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse workflow dag"})
+			return
+		}
+		if req.Nodes != nil {
+			dagStruct.Nodes = req.Nodes
+		}
+		if req.Edges != nil {
+			dagStruct.Edges = req.Edges
+		}
+		if req.InputSchema != nil {
+			var schema interface{}
+			if err := json.Unmarshal(req.InputSchema, &schema); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid inputSchema: " + err.Error()})
+				return
+			}
+			dagStruct.InputSchema = schema
+		}
+		if req.Timezone != nil {
+			dagStruct.Timezone = *req.Timezone
+		}
 	}
 
 	wf, err := h.WorkflowService.UpdateWorkflow(c.Request.Context(), workflowID, req.Name, dagStruct)
@@ -253,6 +295,8 @@ func (h *WorkflowHandler) GetVersion(c *gin.Context) {
 		"name":          version.Name,
 		"nodes":         dagStruct.Nodes,
 		"edges":         dagStruct.Edges,
+		"inputSchema":   dagStruct.InputSchema,
+		"timezone":      dagStruct.Timezone,
 		"createdAt":     version.CreatedAt,
 	})
 }
@@ -0,0 +1,49 @@
+-- Helpers for driving workflows from table changes via LISTEN/NOTIFY.
+-- A workflow whose start node has a "postgres" trigger listening on the same
+-- channel is started once per notification.
+--
+-- Usage:
+--   SELECT install_workflow_notify_trigger('public.orders', 'orders_changed');
+--   SELECT uninstall_workflow_notify_trigger('public.orders', 'orders_changed');
+--
+-- NOTIFY payloads are limited to 8000 bytes; rows larger than that should
+-- notify with their primary key only and be re-read by the workflow.
+
+-- Trigger function that publishes the changed row as JSON on the channel
+-- passed as the first trigger argument
+CREATE OR REPLACE FUNCTION workflow_notify() RETURNS TRIGGER AS $$
+DECLARE
+    payload JSONB;
+BEGIN
+    payload := jsonb_build_object(
+        'schema', TG_TABLE_SCHEMA,
+        'table', TG_TABLE_NAME,
+        'operation', TG_OP,
+        'record', CASE WHEN TG_OP = 'DELETE' THEN NULL ELSE to_jsonb(NEW) END,
+        'old_record', CASE WHEN TG_OP = 'INSERT' THEN NULL ELSE to_jsonb(OLD) END
+    );
+    PERFORM pg_notify(TG_ARGV[0], payload::TEXT);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Installs an AFTER INSERT/UPDATE/DELETE row trigger on target_table that
+-- notifies channel_name
+CREATE OR REPLACE FUNCTION install_workflow_notify_trigger(target_table REGCLASS, channel_name TEXT) RETURNS VOID AS $$
+DECLARE
+    trigger_name TEXT := 'workflow_notify_' || channel_name;
+BEGIN
+    EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', trigger_name, target_table);
+    EXECUTE format(
+        'CREATE TRIGGER %I AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION workflow_notify(%L)',
+        trigger_name, target_table, channel_name
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+-- Removes a trigger previously installed with install_workflow_notify_trigger
+CREATE OR REPLACE FUNCTION uninstall_workflow_notify_trigger(target_table REGCLASS, channel_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', 'workflow_notify_' || channel_name, target_table);
+END;
+$$ LANGUAGE plpgsql;
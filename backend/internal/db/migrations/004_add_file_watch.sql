@@ -0,0 +1,23 @@
+-- Binary payloads (files, attachments) referenced from execution data by ID
+CREATE TABLE IF NOT EXISTS binary_data (
+    id UUID PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_binary_data_created_at ON binary_data(created_at DESC);
+
+-- Files already handed to a workflow by a file_watch trigger
+CREATE TABLE IF NOT EXISTS file_watch_ledger (
+    workflow_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    node_id VARCHAR(255) NOT NULL,
+    path TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    mod_time TIMESTAMP NOT NULL,
+    execution_id UUID,
+    processed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workflow_id, node_id, path)
+);
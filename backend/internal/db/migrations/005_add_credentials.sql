@@ -0,0 +1,11 @@
+-- Named connection secrets referenced from node data instead of inline DSNs
+CREATE TABLE IF NOT EXISTS credentials (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    type VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_credentials_type ON credentials(type);
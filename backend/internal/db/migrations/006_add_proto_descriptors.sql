@@ -0,0 +1,8 @@
+-- Uploaded protobuf descriptor sets for gRPC nodes calling servers without reflection
+CREATE TABLE IF NOT EXISTS proto_descriptors (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    data BYTEA NOT NULL,
+    services TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,29 @@
+-- Trigger names are 'workflow_notify_' || channel_name, and Postgres truncates
+-- identifiers to 63 bytes, so two long channels on one table could share a
+-- trigger. Channels longer than 47 bytes are rejected instead.
+
+CREATE OR REPLACE FUNCTION install_workflow_notify_trigger(target_table REGCLASS, channel_name TEXT) RETURNS VOID AS $$
+DECLARE
+    trigger_name TEXT := 'workflow_notify_' || channel_name;
+BEGIN
+    IF octet_length(trigger_name) > 63 THEN
+        RAISE EXCEPTION 'channel name "%" is longer than 47 bytes', channel_name;
+    END IF;
+    EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', trigger_name, target_table);
+    EXECUTE format(
+        'CREATE TRIGGER %I AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION workflow_notify(%L)',
+        trigger_name, target_table, channel_name
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION uninstall_workflow_notify_trigger(target_table REGCLASS, channel_name TEXT) RETURNS VOID AS $$
+DECLARE
+    trigger_name TEXT := 'workflow_notify_' || channel_name;
+BEGIN
+    IF octet_length(trigger_name) > 63 THEN
+        RAISE EXCEPTION 'channel name "%" is longer than 47 bytes', channel_name;
+    END IF;
+    EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', trigger_name, target_table);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,10 @@
+package models
+
+// BinaryRef points at a file stored in the binary_data table. Nodes pass this
+// reference around instead of the raw bytes to keep execution data small.
+type BinaryRef struct {
+	BinaryID string `json:"binary_id"`
+	FileName string `json:"file_name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Credential holds connection secrets that nodes reference by ID or name.
+// Data is write-only through the API and is never returned to clients.
+type Credential struct {
+	ID        string                 `json:"id" db:"id"`
+	Name      string                 `json:"name" db:"name"`
+	Type      string                 `json:"type" db:"type"`
+	Data      map[string]interface{} `json:"data,omitempty" db:"data"`
+	CreatedAt time.Time              `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time              `json:"updatedAt" db:"updated_at"`
+}
+
+// Credential types
+const (
+	CredentialTypePostgres = "postgres"
+	CredentialTypeRedis    = "redis"
+	CredentialTypeSMTP     = "smtp"
+	CredentialTypeCrypto   = "crypto"
+	CredentialTypeJWT      = "jwt"
+	CredentialTypeS3       = "s3"
+	CredentialTypeNATS     = "nats"
+	CredentialTypeTLS      = "tls"
+)
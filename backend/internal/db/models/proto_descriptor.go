@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ProtoDescriptor is an uploaded FileDescriptorSet that grpc nodes reference
+// by ID or name. Data is only read by worker activities.
+type ProtoDescriptor struct {
+	ID        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Services  []string  `json:"services" db:"services"`
+	Data      []byte    `json:"-" db:"data"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Workflow represents a workflow definition in the database
 type Workflow struct {
@@ -24,8 +27,10 @@ type WorkflowVersion struct {
 
 // DAGStructure represents the structure of a workflow DAG
 type DAGStructure struct {
-	Nodes []Node `json:"nodes"`
-	Edges []Edge `json:"edges"`
+	Nodes       []Node      `json:"nodes"`
+	Edges       []Edge      `json:"edges"`
+	InputSchema interface{} `json:"inputSchema,omitempty"` // JSON Schema for run payloads
+	Timezone    string      `json:"timezone,omitempty"`    // IANA zone used by date nodes, defaults to UTC
 }
 
 // Node represents a workflow node
@@ -38,9 +43,10 @@ type Node struct {
 
 // Edge represents a connection between nodes
 type Edge struct {
-	ID     string `json:"id"`
-	Source string `json:"source"`
-	Target string `json:"target"`
+	ID           string `json:"id"`
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	SourceHandle string `json:"sourceHandle,omitempty"` // output of a multi-output node, e.g. "discarded"
 }
 
 // Position represents node position on canvas
@@ -51,7 +57,37 @@ type Position struct {
 
 // StartNodeData represents data for start node
 type StartNodeData struct {
-	Label string `json:"label,omitempty"`
+	Label   string         `json:"label,omitempty"`
+	Trigger *TriggerConfig `json:"trigger,omitempty"` // nil means the workflow is only started manually
+}
+
+// Trigger types supported on start nodes
+const (
+	TriggerTypeManual    = "manual"
+	TriggerTypePostgres  = "postgres"
+	TriggerTypeFileWatch = "file_watch"
+	TriggerTypeEmail     = "email"
+	TriggerTypeNATS      = "nats"
+)
+
+// TriggerConfig describes an event source that starts executions automatically
+type TriggerConfig struct {
+	Type string `json:"type"`
+
+	// Postgres LISTEN/NOTIFY
+	Channel string `json:"channel,omitempty"`
+
+	// File watch; Directory is relative to the server's FILE_WATCH_ROOT
+	Directory string `json:"directory,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`  // glob matched against file names, defaults to "*"
+	Debounce  string `json:"debounce,omitempty"` // how long a file must stay unchanged, e.g. "5s"
+
+	// Inbound email; recipient address accepted by the embedded SMTP server
+	Address string `json:"address,omitempty"`
+
+	// NATS subscription; Subject may use * and > wildcards
+	Subject string `json:"subject,omitempty"`
+	Queue   string `json:"queue,omitempty"` // queue group, defaults to one group per workflow
 }
 
 // HttpNodeData represents data for HTTP node
@@ -61,6 +97,9 @@ type HttpNodeData struct {
 	Headers map[string]string `json:"headers,omitempty"`
 	Query   map[string]string `json:"query,omitempty"`
 	Body    interface{}       `json:"body,omitempty"`
+	// ResponseFormat "file" stores the response body as binary data instead
+	// of text
+	ResponseFormat string `json:"responseFormat,omitempty"`
 }
 
 // OutputNodeData represents data for output node
@@ -74,6 +113,475 @@ type CodeNodeData struct {
 	Label string `json:"label,omitempty"`
 }
 
+// SetNodeData represents data for set (edit fields) node
+type SetNodeData struct {
+	Assignments []SetAssignment `json:"assignments"`
+	KeepOnlySet bool            `json:"keepOnlySet,omitempty"` // drop input fields that are not assigned
+	Label       string          `json:"label,omitempty"`
+}
+
+// SetAssignment writes one value into the node output.
+// Value is a literal; strings may reference the input with {{ path }}.
+type SetAssignment struct {
+	Name  string      `json:"name"`           // target dot-path, e.g. "user.id"
+	Value interface{} `json:"value"`          // literal or "{{ data.id }}"
+	Type  string      `json:"type,omitempty"` // string, number, boolean, object, array; empty keeps the value as is
+}
+
+// FilterNodeData represents data for filter node
+type FilterNodeData struct {
+	Path          string        `json:"path,omitempty"`       // location of the array in the input; empty uses the input itself
+	Combinator    string        `json:"combinator,omitempty"` // how groups combine: "and" (default) or "or"
+	Groups        []FilterGroup `json:"groups"`
+	EmitDiscarded bool          `json:"emitDiscarded,omitempty"` // expose non-matching items on the "discarded" output
+	Label         string        `json:"label,omitempty"`
+}
+
+// FilterGroup is a set of conditions joined by its combinator
+type FilterGroup struct {
+	Combinator string            `json:"combinator,omitempty"` // "and" (default) or "or"
+	Conditions []FilterCondition `json:"conditions"`
+}
+
+// FilterCondition compares one field of an item against a value
+type FilterCondition struct {
+	Field      string      `json:"field,omitempty"` // dot-path within the item; empty compares the item itself
+	Operator   string      `json:"operator"`
+	Value      interface{} `json:"value,omitempty"`
+	IgnoreCase bool        `json:"ignoreCase,omitempty"`
+}
+
+// Filter operators
+const (
+	FilterOpEquals      = "equals"
+	FilterOpNotEquals   = "not_equals"
+	FilterOpContains    = "contains"
+	FilterOpNotContains = "not_contains"
+	FilterOpStartsWith  = "starts_with"
+	FilterOpEndsWith    = "ends_with"
+	FilterOpRegex       = "regex"
+	FilterOpGt          = "gt"
+	FilterOpGte         = "gte"
+	FilterOpLt          = "lt"
+	FilterOpLte         = "lte"
+	FilterOpBefore      = "before"
+	FilterOpAfter       = "after"
+	FilterOpIsEmpty     = "is_empty"
+	FilterOpIsNotEmpty  = "is_not_empty"
+	FilterOpIsType      = "is_type" // value: string, number, boolean, object, array or null
+)
+
+// ItemsNodeData represents data for items (list operations) node
+type ItemsNodeData struct {
+	Path       string           `json:"path,omitempty"` // location of the array in the input; empty uses the input itself
+	Operations []ItemsOperation `json:"operations"`     // applied in order
+	Label      string           `json:"label,omitempty"`
+}
+
+// ItemsOperation is one step of an items node pipeline
+type ItemsOperation struct {
+	Type   string      `json:"type"`             // sort, limit, dedupe, reverse, flatten
+	Sort   []SortField `json:"sort,omitempty"`   // sort: fields in priority order
+	Limit  int         `json:"limit,omitempty"`  // limit: max items kept, 0 keeps all after offset
+	Offset int         `json:"offset,omitempty"` // limit: items skipped first
+	Keys   []string    `json:"keys,omitempty"`   // dedupe: fields forming the identity; empty compares whole items
+	Depth  int         `json:"depth,omitempty"`  // flatten: levels to flatten, defaults to 1
+}
+
+// SortField orders items by one field
+type SortField struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction,omitempty"` // "asc" (default) or "desc"
+}
+
+// Items node operation types
+const (
+	ItemsOpSort    = "sort"
+	ItemsOpLimit   = "limit"
+	ItemsOpDedupe  = "dedupe"
+	ItemsOpReverse = "reverse"
+	ItemsOpFlatten = "flatten"
+)
+
+// AggregateNodeData represents data for aggregate (group-by) node
+type AggregateNodeData struct {
+	Path         string        `json:"path,omitempty"`    // location of the array in the input; empty uses the input itself
+	GroupBy      []string      `json:"groupBy,omitempty"` // fields forming a group; empty aggregates all items together
+	Aggregations []Aggregation `json:"aggregations"`
+	Label        string        `json:"label,omitempty"`
+}
+
+// Aggregation computes one value per group
+type Aggregation struct {
+	Function  string `json:"function"`            // count, sum, avg, min, max, first, last, concat, distinct
+	Field     string `json:"field,omitempty"`     // dot-path within each item; optional for count
+	As        string `json:"as,omitempty"`        // output field, defaults to "<function>_<field>"
+	Separator string `json:"separator,omitempty"` // concat: joins values, defaults to ", "
+}
+
+// AggregationOutputName returns the field an aggregation writes to
+func AggregationOutputName(agg Aggregation) string {
+	if agg.As != "" {
+		return agg.As
+	}
+	if agg.Field == "" {
+		return agg.Function
+	}
+	return agg.Function + "_" + agg.Field
+}
+
+// Aggregate functions
+const (
+	AggCount    = "count"
+	AggSum      = "sum"
+	AggAvg      = "avg"
+	AggMin      = "min"
+	AggMax      = "max"
+	AggFirst    = "first"
+	AggLast     = "last"
+	AggConcat   = "concat"
+	AggDistinct = "distinct"
+)
+
+// BatchNodeData represents data for split-in-batches node
+type BatchNodeData struct {
+	Path  string `json:"path,omitempty"`  // location of the array in the input; empty uses the input itself
+	Size  int    `json:"size"`            // items per batch
+	Delay string `json:"delay,omitempty"` // pause between batches, e.g. "1s"
+	Label string `json:"label,omitempty"`
+}
+
+// PostgresNodeData represents data for Postgres query node
+type PostgresNodeData struct {
+	Credential string        `json:"credential"`           // credential ID or name
+	Query      string        `json:"query"`                // statement with $1, $2... placeholders
+	Parameters []interface{} `json:"parameters,omitempty"` // bound positionally; strings may use {{ path }}
+	Mode       string        `json:"mode,omitempty"`       // single (default), each, transaction
+	RowLimit   int           `json:"rowLimit,omitempty"`   // maximum rows returned; 0 means unlimited
+	Label      string        `json:"label,omitempty"`
+}
+
+// Postgres node execution modes
+const (
+	PostgresModeSingle      = "single"      // run once against the node input
+	PostgresModeEach        = "each"        // run once per input item, each committed on its own
+	PostgresModeTransaction = "transaction" // run once per input item inside one transaction
+)
+
+// RedisNodeData represents data for Redis node. String fields may use {{ path }}.
+type RedisNodeData struct {
+	Credential  string      `json:"credential"` // credential ID or name
+	Operation   string      `json:"operation"`
+	Key         string      `json:"key,omitempty"`
+	Field       string      `json:"field,omitempty"`       // hget, hset, hdel
+	Value       interface{} `json:"value,omitempty"`       // set, hset, lpush; non-strings are stored as JSON
+	TTL         string      `json:"ttl,omitempty"`         // set: expiry, e.g. "10m"
+	IfNotExists bool        `json:"ifNotExists,omitempty"` // set: only write a missing key (SET NX), for locks
+	By          int64       `json:"by,omitempty"`          // incr: increment, defaults to 1
+	Channel     string      `json:"channel,omitempty"`     // publish
+	Label       string      `json:"label,omitempty"`
+}
+
+// Redis node operations
+const (
+	RedisOpGet     = "get"
+	RedisOpSet     = "set"
+	RedisOpDelete  = "delete"
+	RedisOpIncr    = "incr"
+	RedisOpHGet    = "hget"
+	RedisOpHSet    = "hset"
+	RedisOpHGetAll = "hgetall"
+	RedisOpHDel    = "hdel"
+	RedisOpLPush   = "lpush"
+	RedisOpRPop    = "rpop"
+	RedisOpPublish = "publish"
+)
+
+// EmailNodeData represents data for send email node. String fields may use {{ path }}.
+type EmailNodeData struct {
+	Credential  string   `json:"credential"`     // smtp credential ID or name
+	From        string   `json:"from,omitempty"` // defaults to the credential's from address
+	To          []string `json:"to,omitempty"`   // entries may hold comma-separated addresses
+	Cc          []string `json:"cc,omitempty"`
+	Bcc         []string `json:"bcc,omitempty"`
+	ReplyTo     string   `json:"replyTo,omitempty"`
+	Subject     string   `json:"subject"`
+	Text        string   `json:"text,omitempty"`
+	HTML        string   `json:"html,omitempty"`
+	Attachments []string `json:"attachments,omitempty"` // paths to binary references in the input
+	Label       string   `json:"label,omitempty"`
+}
+
+// CSVNodeData represents data for CSV parse/generate node
+type CSVNodeData struct {
+	Mode             string   `json:"mode"`                       // parse or generate
+	Path             string   `json:"path,omitempty"`             // location of the text, binary reference or array in the input
+	Delimiter        string   `json:"delimiter,omitempty"`        // single character, defaults to ","
+	HeaderRow        *bool    `json:"headerRow,omitempty"`        // first row holds column names; defaults to true
+	InferTypes       bool     `json:"inferTypes,omitempty"`       // parse: convert numbers, booleans and empty cells
+	LazyQuotes       bool     `json:"lazyQuotes,omitempty"`       // parse: accept bare quotes inside fields
+	TrimLeadingSpace bool     `json:"trimLeadingSpace,omitempty"` // parse: ignore spaces before fields
+	Columns          []string `json:"columns,omitempty"`          // generate: columns in order; defaults to all keys sorted
+	Output           string   `json:"output,omitempty"`           // generate: text (default) or file
+	FileName         string   `json:"fileName,omitempty"`         // generate: name of the file output
+	Label            string   `json:"label,omitempty"`
+}
+
+// ParseNodeData represents data for XML/HTML parse node
+type ParseNodeData struct {
+	Format string         `json:"format"`           // xml or html
+	Path   string         `json:"path,omitempty"`   // location of the text or binary reference in the input
+	Fields []ExtractField `json:"fields,omitempty"` // values to extract; xml without fields converts the whole document
+	Label  string         `json:"label,omitempty"`
+}
+
+// ExtractField names a value selected from a parsed document
+type ExtractField struct {
+	Name      string `json:"name"`
+	CSS       string `json:"css,omitempty"`       // CSS selector (html only)
+	XPath     string `json:"xpath,omitempty"`     // XPath expression
+	Attribute string `json:"attribute,omitempty"` // read this attribute instead of the text
+	HTML      bool   `json:"html,omitempty"`      // return the inner markup instead of the text
+	All       bool   `json:"all,omitempty"`       // return every match as an array
+}
+
+// TemplateNodeData represents data for template rendering node
+type TemplateNodeData struct {
+	Template string `json:"template"`           // Go template source rendered against the node input
+	Engine   string `json:"engine,omitempty"`   // text (default) or html
+	Output   string `json:"output,omitempty"`   // text (default) or file
+	FileName string `json:"fileName,omitempty"` // file output name
+	MimeType string `json:"mimeType,omitempty"` // file output type; detected from the name when empty
+	Label    string `json:"label,omitempty"`
+}
+
+// DatetimeNodeData represents data for date and time node. Value and Other
+// may use {{ path }}.
+type DatetimeNodeData struct {
+	Operation  string   `json:"operation"`
+	Value      string   `json:"value,omitempty"`      // date to operate on; empty means now
+	Layout     string   `json:"layout,omitempty"`     // input layout (Go reference layout or a named one); empty tries common formats
+	Timezone   string   `json:"timezone,omitempty"`   // zone for parsing and output; defaults to the workflow timezone
+	ToTimezone string   `json:"toTimezone,omitempty"` // convert: target zone
+	Format     string   `json:"format,omitempty"`     // output layout, defaults to RFC3339
+	Amount     int      `json:"amount,omitempty"`     // add, subtract, addBusinessDays
+	Unit       string   `json:"unit,omitempty"`       // years, months, weeks, days, hours, minutes, seconds, milliseconds; periods for startOf/endOf
+	Other      string   `json:"other,omitempty"`      // diff, businessDaysBetween: second date, defaults to now
+	WeekStart  string   `json:"weekStart,omitempty"`  // startOf/endOf week: monday (default) or sunday
+	Holidays   []string `json:"holidays,omitempty"`   // business-day math: dates (YYYY-MM-DD) skipped besides weekends
+	Label      string   `json:"label,omitempty"`
+}
+
+// Date and time node operations
+const (
+	DatetimeOpFormat              = "format"
+	DatetimeOpConvert             = "convert"
+	DatetimeOpAdd                 = "add"
+	DatetimeOpSubtract            = "subtract"
+	DatetimeOpDiff                = "diff"
+	DatetimeOpStartOf             = "startOf"
+	DatetimeOpEndOf               = "endOf"
+	DatetimeOpAddBusinessDays     = "addBusinessDays"
+	DatetimeOpBusinessDaysBetween = "businessDaysBetween"
+)
+
+// DatetimeUnit returns the singular form of a date unit ("days" -> "day"),
+// or "" when the unit is unknown
+func DatetimeUnit(unit string) string {
+	u := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unit)), "s")
+	switch u {
+	case "year", "quarter", "month", "week", "day", "hour", "minute", "second", "millisecond":
+		return u
+	}
+	return ""
+}
+
+// CryptoNodeData represents data for crypto node. Value may use {{ path }};
+// objects and arrays are serialized as JSON first.
+type CryptoNodeData struct {
+	Operation  string `json:"operation"`
+	Value      string `json:"value,omitempty"`
+	Algorithm  string `json:"algorithm,omitempty"`  // hash, hmac: md5, sha1, sha256 (default), sha512
+	Encoding   string `json:"encoding,omitempty"`   // hex, base64 or base64url; hash/hmac default to hex, others to base64
+	Credential string `json:"credential,omitempty"` // hmac, encrypt, decrypt: crypto credential ID or name holding the key
+	Length     int    `json:"length,omitempty"`     // random: characters to generate, defaults to 32
+	Charset    string `json:"charset,omitempty"`    // random: alphanumeric (default), numeric, hex or base64url
+	Version    int    `json:"version,omitempty"`    // uuid: 4 (default) or 7
+	Label      string `json:"label,omitempty"`
+}
+
+// Crypto node operations
+const (
+	CryptoOpHash    = "hash"
+	CryptoOpHMAC    = "hmac"
+	CryptoOpEncode  = "encode"
+	CryptoOpDecode  = "decode"
+	CryptoOpUUID    = "uuid"
+	CryptoOpRandom  = "random"
+	CryptoOpEncrypt = "encrypt"
+	CryptoOpDecrypt = "decrypt"
+)
+
+// JWTNodeData represents data for jwt node. Token, Subject, Issuer, Audience
+// and claim values may use {{ path }}.
+type JWTNodeData struct {
+	Operation  string                 `json:"operation"`            // sign or verify
+	Algorithm  string                 `json:"algorithm,omitempty"`  // HS256, RS256 or ES256; verify accepts any of them when empty
+	Credential string                 `json:"credential,omitempty"` // jwt credential ID or name holding secret or PEM keys
+	JWKSURL    string                 `json:"jwksUrl,omitempty"`    // verify: fetch public keys instead of using a credential
+	Claims     map[string]interface{} `json:"claims,omitempty"`     // sign: custom claims
+	Subject    string                 `json:"subject,omitempty"`
+	Issuer     string                 `json:"issuer,omitempty"`    // sign: iss claim; verify: required iss
+	Audience   string                 `json:"audience,omitempty"`  // sign: aud claim; verify: required aud
+	ExpiresIn  string                 `json:"expiresIn,omitempty"` // sign: lifetime, e.g. "5m"
+	KeyID      string                 `json:"keyId,omitempty"`     // sign: kid header
+	Token      string                 `json:"token,omitempty"`     // verify: token, optionally prefixed with "Bearer "
+	Leeway     string                 `json:"leeway,omitempty"`    // verify: allowed clock skew, e.g. "30s"
+	Label      string                 `json:"label,omitempty"`
+}
+
+// GraphQLNodeData represents data for GraphQL request node. Endpoint, header
+// values and variables may use {{ path }}.
+type GraphQLNodeData struct {
+	Endpoint      string                 `json:"endpoint"`
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Headers       map[string]string      `json:"headers,omitempty"`
+	AllowPartial  bool                   `json:"allowPartial,omitempty"` // pass data through when errors accompany it
+	Label         string                 `json:"label,omitempty"`
+}
+
+// GrpcNodeData represents data for gRPC request node. Address, metadata
+// values and request strings may use {{ path }}.
+type GrpcNodeData struct {
+	Address            string            `json:"address"`              // host:port
+	Method             string            `json:"method"`               // fully-qualified, e.g. "pkg.Service/Method"
+	Request            interface{}       `json:"request,omitempty"`    // request message as JSON
+	Descriptor         string            `json:"descriptor,omitempty"` // uploaded descriptor set ID or name; empty uses server reflection
+	Metadata           map[string]string `json:"metadata,omitempty"`   // sent as request headers
+	TLS                bool              `json:"tls,omitempty"`        // connect with TLS using the system roots
+	ServerName         string            `json:"serverName,omitempty"` // TLS server name override
+	InsecureSkipVerify bool              `json:"insecureSkipVerify,omitempty"`
+	Credential         string            `json:"credential,omitempty"` // tls credential with a CA bundle and/or client certificate; implies tls
+	Timeout            string            `json:"timeout,omitempty"`    // call deadline, e.g. "10s"
+	Label              string            `json:"label,omitempty"`
+}
+
+// MaxGrpcTimeout bounds the call deadline of a grpc node
+const MaxGrpcTimeout = 10 * time.Minute
+
+// S3NodeData represents data for S3-compatible object storage node. Bucket,
+// Key and Prefix may use {{ path }}.
+type S3NodeData struct {
+	Operation     string `json:"operation"`
+	Credential    string `json:"credential"`          // s3 credential ID or name
+	Endpoint      string `json:"endpoint,omitempty"`  // overrides the credential's endpoint, e.g. "http://minio:9000"
+	Region        string `json:"region,omitempty"`    // overrides the credential's region
+	PathStyle     bool   `json:"pathStyle,omitempty"` // address buckets as endpoint/bucket instead of bucket.endpoint
+	Bucket        string `json:"bucket"`
+	Key           string `json:"key,omitempty"`           // put, get, delete, presign
+	Path          string `json:"path,omitempty"`          // put: location of the content in the input; empty uses the input itself
+	ContentType   string `json:"contentType,omitempty"`   // put: defaults to the binary data's type
+	Prefix        string `json:"prefix,omitempty"`        // list
+	Recursive     bool   `json:"recursive,omitempty"`     // list: include keys below "/" instead of grouping them into prefixes
+	MaxKeys       int    `json:"maxKeys,omitempty"`       // list: defaults to 1000
+	Expires       string `json:"expires,omitempty"`       // presign: URL lifetime, defaults to 15m
+	PresignMethod string `json:"presignMethod,omitempty"` // presign: GET (default) or PUT
+	Label         string `json:"label,omitempty"`
+}
+
+// S3 node operations
+const (
+	S3OpPut     = "put"
+	S3OpGet     = "get"
+	S3OpList    = "list"
+	S3OpDelete  = "delete"
+	S3OpPresign = "presign"
+)
+
+// FileNodeData represents data for local file node. File and Destination are
+// relative to the worker's FILE_NODE_ROOT and may use {{ path }}.
+type FileNodeData struct {
+	Operation   string `json:"operation"`
+	File        string `json:"file"`                  // file or directory to operate on
+	Destination string `json:"destination,omitempty"` // move: new location
+	Path        string `json:"path,omitempty"`        // write, append: location of the content in the input; empty uses the input itself
+	Output      string `json:"output,omitempty"`      // read: "text" (default) or "file" for binary data
+	Pattern     string `json:"pattern,omitempty"`     // list: glob matched against entry names
+	Recursive   bool   `json:"recursive,omitempty"`   // list: descend into subdirectories; delete: remove directories with their contents
+	Overwrite   bool   `json:"overwrite,omitempty"`   // write, move: replace an existing file
+	Label       string `json:"label,omitempty"`
+}
+
+// File node operations
+const (
+	FileOpRead   = "read"
+	FileOpWrite  = "write"
+	FileOpAppend = "append"
+	FileOpList   = "list"
+	FileOpMove   = "move"
+	FileOpDelete = "delete"
+)
+
+// CompressNodeData represents data for archive and compression node
+type CompressNodeData struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`               // location of the binary data (or, for gzip, text) in the input
+	FileName  string `json:"fileName,omitempty"` // zip, tar, gzip: name of the created file; may use {{ path }}
+	Gzip      bool   `json:"gzip,omitempty"`     // tar: compress the archive as .tar.gz
+	Label     string `json:"label,omitempty"`
+}
+
+// Compress node operations
+const (
+	CompressOpZip    = "zip"
+	CompressOpUnzip  = "unzip"
+	CompressOpGzip   = "gzip"
+	CompressOpGunzip = "gunzip"
+	CompressOpTar    = "tar"
+	CompressOpUntar  = "untar"
+)
+
+// NATSNodeData represents data for NATS node
+type NATSNodeData struct {
+	Operation  string            `json:"operation"` // publish or request
+	Credential string            `json:"credential"`
+	Subject    string            `json:"subject"`           // may use {{ path }}
+	Payload    interface{}       `json:"payload,omitempty"` // strings are sent as is, other values as JSON; defaults to the input
+	Headers    map[string]string `json:"headers,omitempty"`
+	Timeout    string            `json:"timeout,omitempty"` // request: how long to wait for a reply, default 5s
+	Label      string            `json:"label,omitempty"`
+}
+
+// NATS node operations
+const (
+	NATSOpPublish = "publish"
+	NATSOpRequest = "request"
+)
+
+// MaxNATSRequestTimeout bounds how long a nats request waits for a reply
+const MaxNATSRequestTimeout = 10 * time.Minute
+
+// ExecNodeData represents data for execute command node. Command must be in
+// the worker's EXEC_ALLOWED_COMMANDS and Env names in its EXEC_ALLOWED_ENV.
+type ExecNodeData struct {
+	Command        string            `json:"command"`
+	Args           []string          `json:"args,omitempty"`           // each may use {{ path }}; no shell is involved
+	Stdin          string            `json:"stdin,omitempty"`          // location of text or binary data in the input piped to the command
+	Env            map[string]string `json:"env,omitempty"`            // values may use {{ path }}
+	Timeout        string            `json:"timeout,omitempty"`        // default 30s
+	ParseJSON      bool              `json:"parseJson,omitempty"`      // parse stdout as JSON into data
+	IgnoreExitCode bool              `json:"ignoreExitCode,omitempty"` // succeed on a non-zero exit code
+	Label          string            `json:"label,omitempty"`
+}
+
+// Exec node timeouts
+const (
+	DefaultExecTimeout = 30 * time.Second
+	MaxExecTimeout     = time.Hour
+)
+
 // WorkflowSummary is a lightweight view for history listings
 type WorkflowSummary struct {
 	ID            string            `json:"id" db:"id"`
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// CredentialService handles credential CRUD. Secrets are stored as given and
+// only ever read back by worker activities.
+type CredentialService struct {
+	DB *sql.DB
+}
+
+func NewCredentialService(db *sql.DB) *CredentialService {
+	return &CredentialService{DB: db}
+}
+
+// CreateCredential persists a new credential
+func (s *CredentialService) CreateCredential(ctx context.Context, name, credType string, data map[string]interface{}) (*models.Credential, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("credential name is required")
+	}
+	if err := checkCredentialName(name); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(credType) == "" {
+		return nil, errors.New("credential type is required")
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	cred := &models.Credential{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Type:      credType,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	_, err = s.DB.ExecContext(ctx,
+		`INSERT INTO credentials (id, name, type, data, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		cred.ID, cred.Name, cred.Type, string(dataJSON), now, now)
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// GetCredential returns a credential by ID without its secret data
+func (s *CredentialService) GetCredential(ctx context.Context, id string) (*models.Credential, error) {
+	row := s.DB.QueryRowContext(ctx, `SELECT id, name, type, created_at, updated_at FROM credentials WHERE id = $1`, id)
+	var cred models.Credential
+	if err := row.Scan(&cred.ID, &cred.Name, &cred.Type, &cred.CreatedAt, &cred.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// ListCredentials returns all credentials without their secret data
+func (s *CredentialService) ListCredentials(ctx context.Context) ([]models.Credential, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT id, name, type, created_at, updated_at FROM credentials ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	creds := []models.Credential{}
+	for rows.Next() {
+		var cred models.Credential
+		if err := rows.Scan(&cred.ID, &cred.Name, &cred.Type, &cred.CreatedAt, &cred.UpdatedAt); err != nil {
+			return nil, err
+		}
+		creds = append(creds, cred)
+	}
+	return creds, rows.Err()
+}
+
+// UpdateCredential renames a credential and/or replaces its data. A nil data
+// map keeps the stored secrets.
+func (s *CredentialService) UpdateCredential(ctx context.Context, id, name string, data map[string]interface{}) (*models.Credential, error) {
+	cred, err := s.GetCredential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) != "" {
+		cred.Name = strings.TrimSpace(name)
+		if err := checkCredentialName(cred.Name); err != nil {
+			return nil, err
+		}
+	}
+	cred.UpdatedAt = time.Now().UTC()
+
+	if data == nil {
+		_, err = s.DB.ExecContext(ctx,
+			`UPDATE credentials SET name = $1, updated_at = $2 WHERE id = $3`,
+			cred.Name, cred.UpdatedAt, id)
+	} else {
+		dataJSON, marshalErr := json.Marshal(data)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		_, err = s.DB.ExecContext(ctx,
+			`UPDATE credentials SET name = $1, data = $2, updated_at = $3 WHERE id = $4`,
+			cred.Name, string(dataJSON), cred.UpdatedAt, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// DeleteCredential removes a credential
+func (s *CredentialService) DeleteCredential(ctx context.Context, id string) error {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM credentials WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// checkCredentialName rejects names that parse as UUIDs: nodes refer to
+// credentials by ID or name, and UUID references are always treated as IDs
+func checkCredentialName(name string) error {
+	if _, err := uuid.Parse(name); err == nil {
+		return errors.New("credential name cannot be a UUID")
+	}
+	return nil
+}
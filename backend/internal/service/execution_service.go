@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/your-org/n8n-clone/internal/db/models"
 	temporalwf "github.com/your-org/n8n-clone/internal/temporal"
+	"github.com/your-org/n8n-clone/pkg/dag"
 )
 
 // ExecutionService coordinates execution persistence and Temporal starts
@@ -26,6 +28,11 @@ func NewExecutionService(db *sql.DB, temporalClient client.Client) *ExecutionSer
 
 // StartExecution creates an execution record and triggers Temporal workflow
 func (s *ExecutionService) StartExecution(ctx context.Context, workflowID string) (string, error) {
+	return s.StartExecutionWithPayload(ctx, workflowID, nil)
+}
+
+// StartExecutionWithPayload starts an execution whose start node emits the given payload
+func (s *ExecutionService) StartExecutionWithPayload(ctx context.Context, workflowID string, payload interface{}) (string, error) {
 	// ensure workflow exists
 	var exists bool
 	if err := s.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM workflows WHERE id = $1)`, workflowID).Scan(&exists); err != nil {
@@ -51,6 +58,7 @@ func (s *ExecutionService) StartExecution(ctx context.Context, workflowID string
 	_, err = s.TemporalClient.ExecuteWorkflow(ctx, options, temporalwf.DAGWorkflow, temporalwf.WorkflowInput{
 		WorkflowID:  workflowID,
 		ExecutionID: execID,
+		Payload:     payload,
 	})
 	if err != nil {
 		return "", err
@@ -59,6 +67,39 @@ func (s *ExecutionService) StartExecution(ctx context.Context, workflowID string
 	return execID, nil
 }
 
+// ValidateInput checks a run payload against the workflow's declared input schema.
+// It returns an *InputValidationError when fields do not match.
+func (s *ExecutionService) ValidateInput(ctx context.Context, workflowID string, payload interface{}) error {
+	var dagJSON string
+	if err := s.DB.QueryRowContext(ctx, `SELECT dag_json FROM workflows WHERE id = $1`, workflowID).Scan(&dagJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWorkflowNotFound
+		}
+		return err
+	}
+
+	var dagStruct models.DAGStructure
+	if err := json.Unmarshal([]byte(dagJSON), &dagStruct); err != nil {
+		return err
+	}
+	if dagStruct.InputSchema == nil {
+		return nil
+	}
+
+	// A run without a body is validated as an empty object
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	fields, err := dag.ValidateInput(dagStruct.InputSchema, payload)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return &InputValidationError{Fields: fields}
+	}
+	return nil
+}
+
 // GetExecution fetches execution by ID
 func (s *ExecutionService) GetExecution(ctx context.Context, executionID string) (*models.Execution, error) {
 	row := s.DB.QueryRowContext(ctx, `SELECT id, workflow_id, status, result_json, error, started_at, finished_at FROM executions WHERE id = $1`, executionID)
@@ -135,3 +176,12 @@ func (s *ExecutionService) DecodeResult(exec *models.Execution) (interface{}, er
 
 // Helper to handle missing workflow
 var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// InputValidationError lists the payload fields that violate a workflow's input schema
+type InputValidationError struct {
+	Fields []dag.FieldError
+}
+
+func (e *InputValidationError) Error() string {
+	return fmt.Sprintf("input validation failed: %d invalid field(s)", len(e.Fields))
+}
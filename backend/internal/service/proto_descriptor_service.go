@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/pkg/protoset"
+)
+
+// ProtoDescriptorService stores the descriptor sets grpc nodes use for
+// servers that do not expose reflection
+type ProtoDescriptorService struct {
+	DB *sql.DB
+}
+
+func NewProtoDescriptorService(db *sql.DB) *ProtoDescriptorService {
+	return &ProtoDescriptorService{DB: db}
+}
+
+// CreateProtoDescriptor validates and stores a serialized FileDescriptorSet
+func (s *ProtoDescriptorService) CreateProtoDescriptor(ctx context.Context, name string, data []byte) (*models.ProtoDescriptor, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("descriptor name is required")
+	}
+	// grpc nodes treat UUID references as IDs, so such a name could never
+	// be used
+	if _, err := uuid.Parse(name); err == nil {
+		return nil, errors.New("descriptor name cannot be a UUID")
+	}
+	files, err := protoset.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &models.ProtoDescriptor{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Services:  protoset.Services(files),
+		CreatedAt: time.Now().UTC(),
+	}
+	if desc.Services == nil {
+		desc.Services = []string{}
+	}
+	_, err = s.DB.ExecContext(ctx,
+		`INSERT INTO proto_descriptors (id, name, data, services, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		desc.ID, desc.Name, data, pq.Array(desc.Services), desc.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// GetProtoDescriptor returns a descriptor by ID without its data
+func (s *ProtoDescriptorService) GetProtoDescriptor(ctx context.Context, id string) (*models.ProtoDescriptor, error) {
+	row := s.DB.QueryRowContext(ctx, `SELECT id, name, services, created_at FROM proto_descriptors WHERE id = $1`, id)
+	var desc models.ProtoDescriptor
+	if err := row.Scan(&desc.ID, &desc.Name, pq.Array(&desc.Services), &desc.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// ListProtoDescriptors returns all descriptors without their data
+func (s *ProtoDescriptorService) ListProtoDescriptors(ctx context.Context) ([]models.ProtoDescriptor, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT id, name, services, created_at FROM proto_descriptors ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	descs := []models.ProtoDescriptor{}
+	for rows.Next() {
+		var desc models.ProtoDescriptor
+		if err := rows.Scan(&desc.ID, &desc.Name, pq.Array(&desc.Services), &desc.CreatedAt); err != nil {
+			return nil, err
+		}
+		descs = append(descs, desc)
+	}
+	return descs, rows.Err()
+}
+
+// DeleteProtoDescriptor removes a descriptor
+func (s *ProtoDescriptorService) DeleteProtoDescriptor(ctx context.Context, id string) error {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM proto_descriptors WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
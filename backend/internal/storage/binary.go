@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// BinaryStore persists binary payloads in Postgres and hands out references
+type BinaryStore struct {
+	DB *sql.DB
+}
+
+func NewBinaryStore(db *sql.DB) *BinaryStore {
+	return &BinaryStore{DB: db}
+}
+
+// Put stores data and returns a reference to it. An empty mimeType is
+// detected from the file name, falling back to content sniffing.
+func (s *BinaryStore) Put(ctx context.Context, fileName, mimeType string, data []byte) (*models.BinaryRef, error) {
+	if mimeType == "" {
+		mimeType = DetectMimeType(fileName, data)
+	}
+
+	ref := &models.BinaryRef{
+		BinaryID: uuid.New().String(),
+		FileName: fileName,
+		MimeType: mimeType,
+		Size:     int64(len(data)),
+	}
+	_, err := s.DB.ExecContext(ctx,
+		`INSERT INTO binary_data (id, file_name, mime_type, size, data, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		ref.BinaryID, ref.FileName, ref.MimeType, ref.Size, data, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// Get loads a stored payload by ID
+func (s *BinaryStore) Get(ctx context.Context, id string) (*models.BinaryRef, []byte, error) {
+	row := s.DB.QueryRowContext(ctx, `SELECT id, file_name, mime_type, size, data FROM binary_data WHERE id = $1`, id)
+	var ref models.BinaryRef
+	var data []byte
+	if err := row.Scan(&ref.BinaryID, &ref.FileName, &ref.MimeType, &ref.Size, &data); err != nil {
+		return nil, nil, err
+	}
+	return &ref, data, nil
+}
+
+// DetectMimeType guesses a MIME type from the file extension, then the content
+func DetectMimeType(fileName string, data []byte) string {
+	if t := mime.TypeByExtension(filepath.Ext(fileName)); t != "" {
+		return t
+	}
+	return http.DetectContentType(data)
+}
@@ -6,18 +6,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/dop251/goja"
 
 	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
 )
 
 // Activities struct holds dependencies for activities
 type Activities struct {
 	DB *sql.DB
+
+	// FileRoot is the directory file nodes are confined to; file nodes are
+	// disabled when it is empty
+	FileRoot string
+
+	// ExecCommands lists the commands exec nodes may run; exec nodes are
+	// disabled when it is empty. ExecEnv names the environment variables
+	// they may see.
+	ExecCommands []string
+	ExecEnv      []string
+
+	clients sharedClients // connection pools for credential-backed nodes
 }
 
 // HttpRequestInput represents input for HTTP request activity
@@ -27,14 +42,17 @@ type HttpRequestInput struct {
 	Headers map[string]string `json:"headers,omitempty"`
 	Query   map[string]string `json:"query,omitempty"`
 	Body    interface{}       `json:"body,omitempty"`
+
+	ResponseFormat string `json:"responseFormat,omitempty"` // "file" stores the body as binary data
 }
 
 // HttpRequestOutput represents output from HTTP request activity
 type HttpRequestOutput struct {
 	StatusCode int                 `json:"status_code"`
-	Headers     map[string][]string `json:"headers"`
-	Body        string              `json:"body"`
-	Data        interface{}         `json:"data,omitempty"` // Can be object or array
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body"`
+	Data       interface{}         `json:"data,omitempty"` // Can be object or array
+	File       *models.BinaryRef   `json:"file,omitempty"` // set instead of Body for file responses
 }
 
 // HttpRequestActivity performs an HTTP request
@@ -86,9 +104,18 @@ func (a *Activities) HttpRequestActivity(ctx context.Context, input HttpRequestI
 	output := &HttpRequestOutput{
 		StatusCode: resp.StatusCode,
 		Headers:    resp.Header,
-		Body:       string(respBody),
 	}
 
+	if input.ResponseFormat == "file" {
+		ref, err := storage.NewBinaryStore(a.DB).Put(ctx, responseFileName(resp), resp.Header.Get("Content-Type"), respBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store response body: %w", err)
+		}
+		output.File = ref
+		return output, nil
+	}
+	output.Body = string(respBody)
+
 	// Try to parse JSON response (can be object or array)
 	var data interface{}
 	if err := json.Unmarshal(respBody, &data); err == nil {
@@ -98,6 +125,18 @@ func (a *Activities) HttpRequestActivity(ctx context.Context, input HttpRequestI
 	return output, nil
 }
 
+// responseFileName takes the file name from Content-Disposition, falling back
+// to the last segment of the request path
+func responseFileName(resp *http.Response) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		return path.Base(params["filename"])
+	}
+	if name := path.Base(resp.Request.URL.Path); name != "/" && name != "." {
+		return name
+	}
+	return "response"
+}
+
 // LoadDAGActivity loads a workflow DAG from the database
 func (a *Activities) LoadDAGActivity(ctx context.Context, workflowID string) (string, error) {
 	row := a.DB.QueryRowContext(ctx, `SELECT dag_json FROM workflows WHERE id = $1`, workflowID)
@@ -0,0 +1,164 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// AggregateInput represents input for the aggregate node activity
+type AggregateInput struct {
+	Aggregate models.AggregateNodeData `json:"aggregate"`
+	Input     interface{}              `json:"input"`
+}
+
+// aggregateGroup accumulates the items sharing one group key
+type aggregateGroup struct {
+	keys  []interface{}
+	items []interface{}
+}
+
+// AggregateActivity groups the input items and computes one row per group.
+// Groups are returned in order of first appearance.
+func (a *Activities) AggregateActivity(ctx context.Context, input AggregateInput) ([]map[string]interface{}, error) {
+	items, err := itemsAt(input.Input, input.Aggregate.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	groups := make(map[string]*aggregateGroup)
+	for _, item := range items {
+		keys := make([]interface{}, len(input.Aggregate.GroupBy))
+		for i, field := range input.Aggregate.GroupBy {
+			keys[i], _ = getPath(item, field)
+		}
+		b, err := json.Marshal(keys)
+		if err != nil {
+			return nil, err
+		}
+		id := string(b)
+		group, ok := groups[id]
+		if !ok {
+			group = &aggregateGroup{keys: keys}
+			groups[id] = group
+			order = append(order, id)
+		}
+		group.items = append(group.items, item)
+	}
+
+	// Without group-by fields an empty input still yields one row of totals
+	if len(input.Aggregate.GroupBy) == 0 && len(order) == 0 {
+		groups["[]"] = &aggregateGroup{}
+		order = append(order, "[]")
+	}
+
+	rows := make([]map[string]interface{}, 0, len(order))
+	for _, id := range order {
+		group := groups[id]
+		row := make(map[string]interface{})
+		for i, field := range input.Aggregate.GroupBy {
+			if err := setPath(row, field, group.keys[i]); err != nil {
+				return nil, err
+			}
+		}
+		for _, agg := range input.Aggregate.Aggregations {
+			value, err := aggregate(agg, group.items)
+			if err != nil {
+				return nil, err
+			}
+			if err := setPath(row, models.AggregationOutputName(agg), value); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func aggregate(agg models.Aggregation, items []interface{}) (interface{}, error) {
+	// Collect present values; missing fields are ignored by every function
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if v, ok := getPath(item, agg.Field); ok && v != nil {
+			values = append(values, v)
+		}
+	}
+
+	switch agg.Function {
+	case models.AggCount:
+		if agg.Field == "" {
+			return float64(len(items)), nil
+		}
+		return float64(len(values)), nil
+	case models.AggSum, models.AggAvg:
+		sum := 0.0
+		for _, v := range values {
+			n, ok := toNumber(v)
+			if !ok {
+				return nil, fmt.Errorf("%s of %q: %v is not a number", agg.Function, agg.Field, v)
+			}
+			sum += n
+		}
+		if agg.Function == models.AggSum {
+			return sum, nil
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return sum / float64(len(values)), nil
+	case models.AggMin, models.AggMax:
+		var best interface{}
+		for _, v := range values {
+			if best == nil {
+				best = v
+				continue
+			}
+			c := compareValues(v, best)
+			if (agg.Function == models.AggMin && c < 0) || (agg.Function == models.AggMax && c > 0) {
+				best = v
+			}
+		}
+		return best, nil
+	case models.AggFirst:
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return values[0], nil
+	case models.AggLast:
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return values[len(values)-1], nil
+	case models.AggConcat:
+		sep := agg.Separator
+		if sep == "" {
+			sep = ", "
+		}
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = stringify(v)
+		}
+		return strings.Join(parts, sep), nil
+	case models.AggDistinct:
+		seen := make(map[string]struct{})
+		distinct := []interface{}{}
+		for _, v := range values {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[string(b)]; ok {
+				continue
+			}
+			seen[string(b)] = struct{}{}
+			distinct = append(distinct, v)
+		}
+		return distinct, nil
+	default:
+		return nil, fmt.Errorf("unknown aggregate function %q", agg.Function)
+	}
+}
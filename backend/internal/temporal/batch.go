@@ -0,0 +1,211 @@
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/pkg/dag"
+)
+
+// batchChildWorkflowsChange marks runs that execute each chunk in a child
+// workflow, which keeps the parent's history small for large arrays. Runs
+// started before it keep running chunks inline when replayed.
+const batchChildWorkflowsChange = "batch-child-workflows"
+
+// BatchChunkInput is the input of the child workflow running one chunk
+type BatchChunkInput struct {
+	Workflow WorkflowInput         `json:"workflow"`
+	DAG      models.DAGStructure   `json:"dag"`
+	Body     []string              `json:"body"`     // body node IDs in execution order
+	Results  map[string]nodeResult `json:"results"`  // outputs feeding the body, including the chunk itself
+	Position map[string]int        `json:"position"` // execution order of the nodes
+	Chunk    []interface{}         `json:"chunk"`
+}
+
+// BatchChunkResult holds each body node's output for one chunk
+type BatchChunkResult struct {
+	Outputs map[string]nodeResult `json:"outputs"`
+}
+
+// BatchChunkWorkflow runs a batch body over one chunk. Node failures keep
+// their message so the parent can store it on the execution.
+func BatchChunkWorkflow(ctx workflow.Context, input BatchChunkInput) (*BatchChunkResult, error) {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+	outputs, err := runBatchChunk(ctx, &input.DAG, input.Body, input.Workflow, input.Results, input.Position, input.Chunk)
+	if err != nil {
+		var failure *nodeFailure
+		if errors.As(err, &failure) {
+			return nil, temporal.NewNonRetryableApplicationError(failure.message, "NodeFailed", failure.cause)
+		}
+		return nil, err
+	}
+	return &BatchChunkResult{Outputs: outputs}, nil
+}
+
+// executeBatch splits the batch node's input into chunks and runs the batch
+// body once per chunk. Each body node's outputs are re-assembled into
+// results, and the re-assembled output of the last body node is returned.
+func executeBatch(ctx workflow.Context, node *models.Node, dagStruct *models.DAGStructure, order []string, input WorkflowInput, nodeInput interface{}, results map[string]nodeResult, position map[string]int) (interface{}, error) {
+	var batchData models.BatchNodeData
+	if err := dag.DecodeNodeData(node.Data, &batchData); err != nil {
+		return nil, failNode(err, "failed to parse batch node data: %v", err)
+	}
+	items, err := itemsAt(nodeInput, batchData.Path)
+	if err != nil {
+		return nil, failNode(err, "batch node '%s' failed: %v", node.ID, err)
+	}
+	var delay time.Duration
+	if batchData.Delay != "" {
+		if delay, err = time.ParseDuration(batchData.Delay); err != nil {
+			return nil, failNode(err, "batch node '%s' has invalid delay: %v", node.ID, err)
+		}
+	}
+
+	body := dag.BatchBody(node.ID, dagStruct.Nodes, dagStruct.Edges)
+	var bodyOrder []string
+	for i, id := range order {
+		if body[id] {
+			bodyOrder = append(bodyOrder, id)
+			position[id] = i
+		}
+	}
+	// Only the outputs feeding the body are handed to each chunk
+	feeds := make(map[string]bool)
+	for _, edge := range dagStruct.Edges {
+		if body[edge.Target] && !body[edge.Source] {
+			feeds[edge.Source] = true
+		}
+	}
+	version := workflow.GetVersion(ctx, batchChildWorkflowsChange, workflow.DefaultVersion, 1)
+
+	outputs := make(map[string][]nodeResult, len(bodyOrder))
+	for chunkIndex, start := 0, 0; start < len(items); chunkIndex, start = chunkIndex+1, start+batchData.Size {
+		if start > 0 && delay > 0 {
+			if err := workflow.Sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+		end := start + batchData.Size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunk := items[start:end]
+
+		// Each chunk sees the results from before the batch plus its own
+		chunkResults := make(map[string]nodeResult, len(feeds)+len(bodyOrder))
+		for id, r := range results {
+			if feeds[id] || version == workflow.DefaultVersion {
+				chunkResults[id] = r
+			}
+		}
+		chunkResults[node.ID] = nodeResult{Default: chunk}
+
+		var chunkOutputs map[string]nodeResult
+		if version == workflow.DefaultVersion {
+			chunkOutputs, err = runBatchChunk(ctx, dagStruct, bodyOrder, input, chunkResults, position, chunk)
+		} else {
+			chunkOutputs, err = runBatchChunkChild(ctx, node.ID, chunkIndex, BatchChunkInput{
+				Workflow: input,
+				DAG:      *dagStruct,
+				Body:     bodyOrder,
+				Results:  chunkResults,
+				Position: position,
+				Chunk:    chunk,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range bodyOrder {
+			outputs[id] = append(outputs[id], chunkOutputs[id])
+		}
+	}
+
+	var combined interface{} = []interface{}{}
+	for _, id := range bodyOrder {
+		results[id] = combineResults(outputs[id])
+		combined = results[id].Default
+	}
+	if len(bodyOrder) == 0 {
+		combined = items
+	}
+	return nodeResult{Default: combined, Handles: map[string]interface{}{"done": combined}}, nil
+}
+
+// runBatchChunk executes the body nodes in order over one chunk. results holds
+// the outputs feeding the body and receives the body's own outputs.
+func runBatchChunk(ctx workflow.Context, dagStruct *models.DAGStructure, bodyOrder []string, input WorkflowInput, results map[string]nodeResult, position map[string]int, chunk []interface{}) (map[string]nodeResult, error) {
+	outputs := make(map[string]nodeResult, len(bodyOrder))
+	var last interface{} = chunk
+	for _, id := range bodyOrder {
+		bodyNode := dag.GetNodeByID(id, dagStruct.Nodes)
+		bodyInput := resolveNodeInput(id, dagStruct.Edges, results, position, last)
+		output, err := executeNode(ctx, bodyNode, dagStruct, input, bodyInput)
+		if err != nil {
+			return nil, err
+		}
+		r := toNodeResult(output)
+		results[id] = r
+		outputs[id] = r
+		last = r.Default
+	}
+	return outputs, nil
+}
+
+// runBatchChunkChild runs one chunk in a child workflow with an ID derived
+// from the parent run, the batch node and the chunk index
+func runBatchChunkChild(ctx workflow.Context, batchID string, chunkIndex int, chunkInput BatchChunkInput) (map[string]nodeResult, error) {
+	cwo := workflow.ChildWorkflowOptions{
+		WorkflowID: fmt.Sprintf("%s-batch-%s-%d", workflow.GetInfo(ctx).WorkflowExecution.ID, batchID, chunkIndex),
+	}
+	var chunkResult BatchChunkResult
+	err := workflow.ExecuteChildWorkflow(workflow.WithChildOptions(ctx, cwo), BatchChunkWorkflow, chunkInput).Get(ctx, &chunkResult)
+	if err != nil {
+		var appErr *temporal.ApplicationError
+		if errors.As(err, &appErr) && appErr.Type() == "NodeFailed" {
+			return nil, failNode(err, "%s", appErr.Message())
+		}
+		return nil, err
+	}
+	return chunkResult.Outputs, nil
+}
+
+// combineResults re-assembles one node's per-chunk results, output by output
+func combineResults(chunks []nodeResult) nodeResult {
+	defaults := make([]interface{}, len(chunks))
+	handleOutputs := make(map[string][]interface{})
+	for i, r := range chunks {
+		defaults[i] = r.Default
+		for handle, v := range r.Handles {
+			handleOutputs[handle] = append(handleOutputs[handle], v)
+		}
+	}
+
+	combined := nodeResult{Default: combineOutputs(defaults)}
+	if len(handleOutputs) > 0 {
+		combined.Handles = make(map[string]interface{}, len(handleOutputs))
+		for handle, outs := range handleOutputs {
+			combined.Handles[handle] = combineOutputs(outs)
+		}
+	}
+	return combined
+}
+
+// combineOutputs concatenates per-chunk arrays; any other outputs are
+// collected into an array with one entry per chunk
+func combineOutputs(outs []interface{}) interface{} {
+	concatenated := []interface{}{}
+	for _, out := range outs {
+		list, ok := normalizeValue(out).([]interface{})
+		if !ok {
+			return outs
+		}
+		concatenated = append(concatenated, list...)
+	}
+	return concatenated
+}
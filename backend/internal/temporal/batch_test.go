@@ -0,0 +1,113 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// newWorkflowTestEnv prepares a test environment running dagStruct, with the
+// database activities replaced by stubs. Stored execution errors are appended
+// to the returned slice.
+func newWorkflowTestEnv(t *testing.T, dagStruct models.DAGStructure) (*testsuite.TestWorkflowEnvironment, *[]string) {
+	t.Helper()
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(DAGWorkflow)
+	env.RegisterWorkflow(BatchChunkWorkflow)
+
+	dagJSON, err := json.Marshal(dagStruct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stubs := map[string]interface{}{
+		"LoadDAGActivity": func(context.Context, string) (string, error) {
+			return string(dagJSON), nil
+		},
+		"StoreExecutionResultActivity":  func(context.Context, string, interface{}) error { return nil },
+		"UpdateExecutionStatusActivity": func(context.Context, string, string) error { return nil },
+	}
+	var stored []string
+	stubs["StoreExecutionErrorActivity"] = func(_ context.Context, _ string, errMsg string) error {
+		stored = append(stored, errMsg)
+		return nil
+	}
+	for name, fn := range stubs {
+		env.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: name})
+	}
+	return env, &stored
+}
+
+func TestBatchRunsChunksInChildWorkflows(t *testing.T) {
+	dagStruct := models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "batch", Type: "batch", Data: map[string]interface{}{"path": "payload.items", "size": 2}},
+			{ID: "body", Type: "code", Data: map[string]interface{}{"code": ""}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "batch"},
+			{ID: "e2", Source: "batch", Target: "body"},
+			{ID: "e3", Source: "body", Target: "out"},
+		},
+	}
+	env, _ := newWorkflowTestEnv(t, dagStruct)
+
+	var children []string
+	env.SetOnChildWorkflowStartedListener(func(info *workflow.Info, _ workflow.Context, _ converter.EncodedValues) {
+		children = append(children, info.WorkflowExecution.ID)
+	})
+
+	payload := map[string]interface{}{"items": []interface{}{1.0, 2.0, 3.0, 4.0, 5.0}}
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec", Payload: payload})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+	var result WorkflowResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	if want := payload["items"]; !reflect.DeepEqual(result.Result, want) {
+		t.Errorf("result = %#v, want the re-assembled items %#v", result.Result, want)
+	}
+	if len(children) != 3 {
+		t.Errorf("started %d child workflows (%v), want one per chunk", len(children), children)
+	}
+}
+
+func TestBatchChunkFailureKeepsNodeMessage(t *testing.T) {
+	dagStruct := models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "batch", Type: "batch", Data: map[string]interface{}{"path": "payload.items", "size": 1}},
+			{ID: "body", Type: "code", Data: map[string]interface{}{"code": `throw new Error("boom")`}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "batch"},
+			{ID: "e2", Source: "batch", Target: "body"},
+			{ID: "e3", Source: "body", Target: "out"},
+		},
+	}
+	env, stored := newWorkflowTestEnv(t, dagStruct)
+	env.RegisterActivity((&Activities{}).CodeExecutionActivity)
+
+	payload := map[string]interface{}{"items": []interface{}{1.0}}
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec", Payload: payload})
+	if env.GetWorkflowError() == nil {
+		t.Fatal("workflow succeeded, want the body failure")
+	}
+	if len(*stored) != 1 || !strings.HasPrefix((*stored)[0], "code execution error: ") || !strings.Contains((*stored)[0], "boom") {
+		t.Errorf("stored errors = %q, want the code node's message", *stored)
+	}
+}
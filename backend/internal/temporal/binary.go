@@ -0,0 +1,97 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// binaryRefsAt reads one binary reference or an array of them from path
+func binaryRefsAt(source interface{}, path string) ([]models.BinaryRef, error) {
+	value, ok := getPath(source, path)
+	if !ok || value == nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("no binary data at %q", path), "InvalidData", nil)
+	}
+	if _, isList := value.([]interface{}); !isList {
+		value = []interface{}{value}
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var refs []models.BinaryRef
+	if err := json.Unmarshal(b, &refs); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%q is not binary data", path), "InvalidData", err)
+	}
+	for _, ref := range refs {
+		if ref.BinaryID == "" {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("%q is not binary data", path), "InvalidData", nil)
+		}
+	}
+	return refs, nil
+}
+
+// asBinaryRef reports whether v is a binary reference object
+func asBinaryRef(v interface{}) (*models.BinaryRef, bool) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	id, ok := obj["binary_id"].(string)
+	if !ok || id == "" {
+		return nil, false
+	}
+	ref := &models.BinaryRef{BinaryID: id}
+	ref.FileName, _ = obj["file_name"].(string)
+	ref.MimeType, _ = obj["mime_type"].(string)
+	return ref, true
+}
+
+// contentAt returns the bytes found at path: a string is used as is, a binary
+// reference is loaded from storage
+func (a *Activities) contentAt(ctx context.Context, source interface{}, path string) ([]byte, error) {
+	value, ok := getPath(source, path)
+	if !ok || value == nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("nothing found at %q", path), "InvalidData", nil)
+	}
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	if ref, ok := asBinaryRef(value); ok {
+		_, data, err := storage.NewBinaryStore(a.DB).Get(ctx, ref.BinaryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load binary data %s: %w", ref.BinaryID, err)
+		}
+		return data, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("%q is %s, expected text or binary data", path, jsonTypeOf(value)), "InvalidData", nil)
+}
+
+// contentWithType is contentAt for nodes that also accept objects and arrays,
+// which are serialized as JSON. The MIME type is returned when known.
+func (a *Activities) contentWithType(ctx context.Context, source interface{}, p string) ([]byte, string, error) {
+	value, ok := getPath(source, p)
+	if ok {
+		if ref, isRef := asBinaryRef(value); isRef {
+			data, err := a.contentAt(ctx, source, p)
+			return data, ref.MimeType, err
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			data, err := json.Marshal(value)
+			return data, "application/json", err
+		}
+	}
+	data, err := a.contentAt(ctx, source, p)
+	return data, "", err
+}
@@ -0,0 +1,375 @@
+package temporal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// Limits on what one node may extract, guarding against archive bombs
+const (
+	maxArchiveEntries = 10000
+	maxExtractedBytes = 100 << 20
+)
+
+// CompressInput represents input for the archive and compression activity
+type CompressInput struct {
+	Compress models.CompressNodeData `json:"compress"`
+	Input    interface{}             `json:"input"`
+}
+
+// CompressOutput represents output from the archive and compression
+// activity: the created or decompressed file, or the extracted files
+type CompressOutput struct {
+	File  *models.BinaryRef  `json:"file,omitempty"`  // zip, tar, gzip, gunzip
+	Files []models.BinaryRef `json:"files,omitempty"` // unzip, untar
+}
+
+// archiveFile is one file going into or coming out of an archive
+type archiveFile struct {
+	name string
+	data []byte
+}
+
+// CompressActivity zips, tars or gzips binary data and reverses each of
+// those. Extracted files get their MIME type from their name or content.
+func (a *Activities) CompressActivity(ctx context.Context, input CompressInput) (*CompressOutput, error) {
+	node := input.Compress
+	source := normalizeValue(input.Input)
+	store := storage.NewBinaryStore(a.DB)
+	fileName := stringify(resolveTemplate(node.FileName, source))
+
+	switch node.Operation {
+	case models.CompressOpZip, models.CompressOpTar:
+		files, err := a.loadArchiveFiles(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		var data []byte
+		var mimeType string
+		if node.Operation == models.CompressOpZip {
+			data, err = writeZip(files)
+			mimeType = "application/zip"
+			if fileName == "" {
+				fileName = "archive.zip"
+			}
+		} else {
+			data, err = writeTar(files, node.Gzip)
+			mimeType = "application/x-tar"
+			if node.Gzip {
+				mimeType = "application/gzip"
+			}
+			if fileName == "" {
+				fileName = "archive.tar"
+				if node.Gzip {
+					fileName += ".gz"
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		ref, err := store.Put(ctx, fileName, mimeType, data)
+		if err != nil {
+			return nil, err
+		}
+		return &CompressOutput{File: ref}, nil
+	case models.CompressOpUnzip, models.CompressOpUntar:
+		archives, err := a.loadArchiveFiles(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		budget := &extractBudget{bytes: maxExtractedBytes}
+		var files []archiveFile
+		for _, archive := range archives {
+			var extracted []archiveFile
+			if node.Operation == models.CompressOpUnzip {
+				extracted, err = readZip(archive, budget)
+			} else {
+				extracted, err = readTar(archive, budget)
+			}
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, extracted...)
+		}
+		output := &CompressOutput{Files: []models.BinaryRef{}}
+		for _, f := range files {
+			ref, err := store.Put(ctx, f.name, "", f.data)
+			if err != nil {
+				return nil, err
+			}
+			output.Files = append(output.Files, *ref)
+		}
+		return output, nil
+	case models.CompressOpGzip:
+		data, _, err := a.contentWithType(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		if fileName == "" {
+			fileName = "data"
+			if value, ok := getPath(source, node.Path); ok {
+				if ref, isRef := asBinaryRef(value); isRef && ref.FileName != "" {
+					fileName = ref.FileName
+				}
+			}
+			fileName += ".gz"
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Name = strings.TrimSuffix(path.Base(fileName), ".gz")
+		zw.ModTime = time.Now()
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		ref, err := store.Put(ctx, fileName, "application/gzip", buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		return &CompressOutput{File: ref}, nil
+	case models.CompressOpGunzip:
+		archives, err := a.loadArchiveFiles(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		if len(archives) != 1 {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("gunzip takes one file, %q holds %d", node.Path, len(archives)), "InvalidData", nil)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(archives[0].data))
+		if err != nil {
+			return nil, invalidArchive(archives[0].name, err)
+		}
+		data, err := (&extractBudget{bytes: maxExtractedBytes}).read(zr)
+		if err != nil {
+			return nil, invalidArchive(archives[0].name, err)
+		}
+		if fileName == "" {
+			fileName = path.Base(zr.Name)
+		}
+		if fileName == "" || fileName == "." || fileName == "/" {
+			fileName = strings.TrimSuffix(strings.TrimSuffix(archives[0].name, ".gz"), ".gzip")
+		}
+		ref, err := store.Put(ctx, fileName, "", data)
+		if err != nil {
+			return nil, err
+		}
+		return &CompressOutput{File: ref}, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown compress operation %q", node.Operation), "InvalidData", nil)
+	}
+}
+
+// loadArchiveFiles loads the binary data at path, one reference or an array
+func (a *Activities) loadArchiveFiles(ctx context.Context, source interface{}, p string) ([]archiveFile, error) {
+	refs, err := binaryRefsAt(source, p)
+	if err != nil {
+		return nil, err
+	}
+	store := storage.NewBinaryStore(a.DB)
+	files := make([]archiveFile, 0, len(refs))
+	for i, ref := range refs {
+		stored, data, err := store.Get(ctx, ref.BinaryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load binary data %s: %w", ref.BinaryID, err)
+		}
+		name := stored.FileName
+		if name == "" {
+			name = fmt.Sprintf("file-%d", i+1)
+		}
+		files = append(files, archiveFile{name: name, data: data})
+	}
+	return files, nil
+}
+
+// entryNames gives each file a clean relative name inside the archive,
+// numbering repeats: report.csv, report (2).csv
+func entryNames(files []archiveFile) []string {
+	seen := make(map[string]bool, len(files))
+	names := make([]string, len(files))
+	for i, f := range files {
+		name, ok := cleanEntryName(f.name)
+		if !ok {
+			name = fmt.Sprintf("file-%d", i+1)
+		}
+		ext := path.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		for n := 2; seen[name]; n++ {
+			name = fmt.Sprintf("%s (%d)%s", base, n, ext)
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+func writeZip(files []archiveFile) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	now := time.Now()
+	for i, name := range entryNames(files) {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(files[i].data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTar(files []archiveFile, compress bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var out io.WriteCloser = nopWriteCloser{&buf}
+	if compress {
+		out = gzip.NewWriter(&buf)
+	}
+	tw := tar.NewWriter(out)
+	now := time.Now()
+	for i, name := range entryNames(files) {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(files[i].data)),
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(files[i].data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// readZip extracts the regular files of a zip archive, skipping directories
+// and macOS resource forks
+func readZip(archive archiveFile, budget *extractBudget) ([]archiveFile, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive.data), int64(len(archive.data)))
+	if err != nil {
+		return nil, invalidArchive(archive.name, err)
+	}
+	var files []archiveFile
+	for _, entry := range zr.File {
+		name, ok := cleanEntryName(entry.Name)
+		if !ok || !entry.Mode().IsRegular() || strings.HasPrefix(name, "__MACOSX/") {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, invalidArchive(archive.name, err)
+		}
+		data, err := budget.read(rc)
+		rc.Close()
+		if err != nil {
+			return nil, invalidArchive(archive.name, err)
+		}
+		files = append(files, archiveFile{name: name, data: data})
+	}
+	return files, nil
+}
+
+// readTar extracts the regular files of a tar archive, which may be gzipped
+func readTar(archive archiveFile, budget *extractBudget) ([]archiveFile, error) {
+	var r io.Reader = bytes.NewReader(archive.data)
+	if bytes.HasPrefix(archive.data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, invalidArchive(archive.name, err)
+		}
+		r = zr
+	}
+	tr := tar.NewReader(r)
+	var files []archiveFile
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, invalidArchive(archive.name, err)
+		}
+		name, ok := cleanEntryName(hdr.Name)
+		if !ok || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := budget.read(tr)
+		if err != nil {
+			return nil, invalidArchive(archive.name, err)
+		}
+		files = append(files, archiveFile{name: name, data: data})
+	}
+}
+
+// cleanEntryName turns an archive entry name into a relative slash path with
+// no ".." segments; ok is false for directories and empty names
+func cleanEntryName(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasSuffix(name, "/") {
+		return "", false
+	}
+	clean := strings.TrimPrefix(path.Clean("/"+name), "/")
+	return clean, clean != ""
+}
+
+// extractBudget tracks the entries and bytes an extraction may still produce
+type extractBudget struct {
+	entries int
+	bytes   int64
+}
+
+var errExtractLimit = errors.New("extraction limit exceeded")
+
+func (b *extractBudget) read(r io.Reader) ([]byte, error) {
+	if b.entries++; b.entries > maxArchiveEntries {
+		return nil, fmt.Errorf("%w: more than %d files", errExtractLimit, maxArchiveEntries)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, b.bytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > b.bytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", errExtractLimit, maxExtractedBytes)
+	}
+	b.bytes -= int64(len(data))
+	return data, nil
+}
+
+func invalidArchive(name string, err error) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("cannot read %q: %v", name, err), "InvalidData", err)
+}
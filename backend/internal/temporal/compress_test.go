@@ -0,0 +1,149 @@
+package temporal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildZip writes the entries in order; names ending in "/" are directories
+func buildZip(t *testing.T, entries ...archiveFile) archiveFile {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Deflate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(e.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return archiveFile{name: "test.zip", data: buf.Bytes()}
+}
+
+func extractedNames(files []archiveFile) []string {
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.name
+	}
+	return names
+}
+
+func TestReadZipKeepsEntriesInside(t *testing.T) {
+	archive := buildZip(t,
+		archiveFile{name: "../../etc/passwd", data: []byte("x")},
+		archiveFile{name: "/abs/file.txt", data: []byte("x")},
+		archiveFile{name: "a/../../../b.txt", data: []byte("x")},
+		archiveFile{name: `..\..\windows.txt`, data: []byte("x")},
+		archiveFile{name: "docs/", data: nil},
+		archiveFile{name: "__MACOSX/._report.csv", data: []byte("x")},
+		archiveFile{name: "docs/report.csv", data: []byte("a,b")},
+	)
+	files, err := readZip(archive, &extractBudget{bytes: maxExtractedBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"etc/passwd", "abs/file.txt", "b.txt", "windows.txt", "docs/report.csv"}
+	if got := extractedNames(files); !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %q, want %q", got, want)
+	}
+}
+
+func TestReadTarKeepsEntriesInside(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Typeflag: tar.TypeReg, Name: "../escape.txt", Size: 1, Mode: 0o644},
+		{Typeflag: tar.TypeSymlink, Name: "link", Linkname: "/etc/passwd", Mode: 0o777},
+		{Typeflag: tar.TypeDir, Name: "dir/", Mode: 0o755},
+		{Typeflag: tar.TypeReg, Name: "dir/ok.txt", Size: 1, Mode: 0o644},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte("x")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Gzipped archives are detected by their magic bytes
+	gzipped, err := writeTar([]archiveFile{{name: "../../gz.txt", data: []byte("x")}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tt := range map[string]struct {
+		data []byte
+		want []string
+	}{
+		"tar":    {buf.Bytes(), []string{"escape.txt", "dir/ok.txt"}},
+		"tar.gz": {gzipped, []string{"gz.txt"}},
+	} {
+		files, err := readTar(archiveFile{name: name, data: tt.data}, &extractBudget{bytes: maxExtractedBytes})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := extractedNames(files); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: names = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestExtractBudget(t *testing.T) {
+	// A small archive that inflates far past the byte budget
+	bomb := buildZip(t, archiveFile{name: "zeros", data: make([]byte, 1<<20)})
+	if len(bomb.data) > 4096 {
+		t.Fatalf("bomb is %d bytes, want a highly compressed entry", len(bomb.data))
+	}
+	_, err := readZip(bomb, &extractBudget{bytes: 1 << 10})
+	if !isNonRetryable(err) || !errors.Is(err, errExtractLimit) {
+		t.Errorf("byte budget: got %v, want a non-retryable extraction limit error", err)
+	}
+
+	// The budget is shared across entries and archives
+	budget := &extractBudget{bytes: 5}
+	two := buildZip(t, archiveFile{name: "a", data: []byte("abc")}, archiveFile{name: "b", data: []byte("abc")})
+	if _, err := readZip(two, budget); !errors.Is(err, errExtractLimit) {
+		t.Errorf("shared bytes: got %v, want an extraction limit error", err)
+	}
+
+	budget = &extractBudget{entries: maxArchiveEntries - 1, bytes: maxExtractedBytes}
+	if _, err := readZip(two, budget); err == nil || !strings.Contains(err.Error(), "files") {
+		t.Errorf("entries: got %v, want the file count limit", err)
+	}
+}
+
+func TestZipRoundTripNumbersDuplicates(t *testing.T) {
+	data, err := writeZip([]archiveFile{
+		{name: "report.csv", data: []byte("1")},
+		{name: "report.csv", data: []byte("2")},
+		{name: "../up.txt", data: []byte("3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := readZip(archiveFile{name: "out.zip", data: data}, &extractBudget{bytes: maxExtractedBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := extractedNames(files), []string{"report.csv", "report (2).csv", "up.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %q, want %q", got, want)
+	}
+	if string(files[1].data) != "2" {
+		t.Errorf("second file = %q, want its own content", files[1].data)
+	}
+}
@@ -0,0 +1,113 @@
+package temporal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// loadCredential fetches a credential by ID (when ref is a UUID) or name and
+// checks its type
+func (a *Activities) loadCredential(ctx context.Context, ref, credType string) (*models.Credential, error) {
+	// Names cannot look like UUIDs, so a reference matches at most one row
+	query := `SELECT id, name, type, data, created_at, updated_at FROM credentials WHERE name = $1`
+	if _, err := uuid.Parse(ref); err == nil {
+		query = `SELECT id, name, type, data, created_at, updated_at FROM credentials WHERE id = $1`
+	}
+	row := a.DB.QueryRowContext(ctx, query, ref)
+	var cred models.Credential
+	var dataJSON []byte
+	if err := row.Scan(&cred.ID, &cred.Name, &cred.Type, &dataJSON, &cred.CreatedAt, &cred.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q not found", ref), "CredentialNotFound", err)
+		}
+		return nil, err
+	}
+	if cred.Type != credType {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q is of type %s, expected %s", ref, cred.Type, credType), "CredentialTypeMismatch", nil)
+	}
+	if err := json.Unmarshal(dataJSON, &cred.Data); err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// credentialString reads a string field from credential data
+func credentialString(cred *models.Credential, key string) string {
+	if v, ok := cred.Data[key]; ok && v != nil {
+		return stringify(v)
+	}
+	return ""
+}
+
+// sharedClients caches long-lived clients (connection pools) per credential
+// so executions on the same worker reuse them. Updating a credential replaces
+// its client; the old one is closed once the executions using it release it.
+type sharedClients struct {
+	mu      sync.Mutex
+	entries map[string]*sharedClient
+}
+
+type sharedClient struct {
+	updatedAt time.Time
+	client    io.Closer
+	refs      int
+	retired   bool
+}
+
+// get returns the client for cred, opening it if needed. Callers must call
+// release once they are done with the client.
+func (c *sharedClients) get(cred *models.Credential, open func() (io.Closer, error)) (io.Closer, func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[cred.ID]
+	if ok && !entry.updatedAt.Equal(cred.UpdatedAt) {
+		entry.retired = true
+		delete(c.entries, cred.ID)
+		if entry.refs == 0 {
+			entry.client.Close()
+		}
+		ok = false
+	}
+	if !ok {
+		client, err := open()
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.entries == nil {
+			c.entries = make(map[string]*sharedClient)
+		}
+		entry = &sharedClient{updatedAt: cred.UpdatedAt, client: client}
+		c.entries[cred.ID] = entry
+	}
+
+	entry.refs++
+	var once sync.Once
+	release := func() {
+		once.Do(func() { c.release(entry) })
+	}
+	return entry.client, release, nil
+}
+
+func (c *sharedClients) release(entry *sharedClient) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.retired && entry.refs == 0 {
+		entry.client.Close()
+	}
+}
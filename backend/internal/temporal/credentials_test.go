@@ -0,0 +1,57 @@
+package temporal
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+type fakeCloser struct {
+	closed bool
+}
+
+func (f *fakeCloser) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestSharedClientsClosesReplacedClientAfterRelease(t *testing.T) {
+	var clients sharedClients
+	cred := &models.Credential{ID: "cred", UpdatedAt: time.Unix(1, 0)}
+	opened := []*fakeCloser{}
+	open := func() (io.Closer, error) {
+		f := &fakeCloser{}
+		opened = append(opened, f)
+		return f, nil
+	}
+
+	first, releaseFirst, err := clients.get(cred, open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, releaseAgain, _ := clients.get(cred, open)
+	if again != first {
+		t.Fatal("expected the cached client to be reused")
+	}
+	releaseAgain()
+
+	cred.UpdatedAt = time.Unix(2, 0)
+	second, releaseSecond, _ := clients.get(cred, open)
+	if second == first {
+		t.Fatal("expected a new client after the credential changed")
+	}
+	if opened[0].closed {
+		t.Fatal("replaced client closed while still in use")
+	}
+	releaseFirst()
+	releaseFirst() // releasing twice must not close anything else
+	if !opened[0].closed {
+		t.Error("replaced client not closed after its last release")
+	}
+	releaseSecond()
+	if opened[1].closed {
+		t.Error("current client closed after release")
+	}
+}
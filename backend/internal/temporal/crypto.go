@@ -0,0 +1,257 @@
+package temporal
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// CryptoInput represents input for the crypto activity
+type CryptoInput struct {
+	Crypto models.CryptoNodeData `json:"crypto"`
+	Input  interface{}           `json:"input"`
+}
+
+// CryptoOutput represents output from the crypto activity
+type CryptoOutput struct {
+	Value string `json:"value"`
+}
+
+// randomCharsets are the alphabets random strings are drawn from
+var randomCharsets = map[string]string{
+	"alphanumeric": "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+	"numeric":      "0123456789",
+	"hex":          "0123456789abcdef",
+	"base64url":    "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_",
+}
+
+// CryptoActivity hashes, signs, encodes, encrypts or generates values.
+// Keys for hmac, encrypt and decrypt come from a crypto credential.
+func (a *Activities) CryptoActivity(ctx context.Context, input CryptoInput) (*CryptoOutput, error) {
+	node := input.Crypto
+	value := stringify(resolveTemplate(node.Value, normalizeValue(input.Input)))
+
+	switch node.Operation {
+	case models.CryptoOpHash:
+		newHash, err := hashFunc(node.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		h := newHash()
+		h.Write([]byte(value))
+		return encodeCryptoOutput(h.Sum(nil), node.Encoding, "hex")
+	case models.CryptoOpHMAC:
+		key, err := a.cryptoKey(ctx, node.Credential)
+		if err != nil {
+			return nil, err
+		}
+		newHash, err := hashFunc(node.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		mac := hmac.New(newHash, key)
+		mac.Write([]byte(value))
+		return encodeCryptoOutput(mac.Sum(nil), node.Encoding, "hex")
+	case models.CryptoOpEncode:
+		return encodeCryptoOutput([]byte(value), node.Encoding, "base64")
+	case models.CryptoOpDecode:
+		data, err := decodeBytes(value, node.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(data) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				"decoded data is not valid UTF-8 text", "InvalidData", nil)
+		}
+		return &CryptoOutput{Value: string(data)}, nil
+	case models.CryptoOpUUID:
+		if node.Version == 7 {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return nil, err
+			}
+			return &CryptoOutput{Value: id.String()}, nil
+		}
+		return &CryptoOutput{Value: uuid.NewString()}, nil
+	case models.CryptoOpRandom:
+		s, err := randomString(node.Length, node.Charset)
+		if err != nil {
+			return nil, err
+		}
+		return &CryptoOutput{Value: s}, nil
+	case models.CryptoOpEncrypt, models.CryptoOpDecrypt:
+		key, err := a.cryptoKey(ctx, node.Credential)
+		if err != nil {
+			return nil, err
+		}
+		gcm, err := newGCM(key, node.Credential)
+		if err != nil {
+			return nil, err
+		}
+		if node.Operation == models.CryptoOpEncrypt {
+			nonce := make([]byte, gcm.NonceSize())
+			if _, err := rand.Read(nonce); err != nil {
+				return nil, err
+			}
+			return encodeCryptoOutput(gcm.Seal(nonce, nonce, []byte(value), nil), node.Encoding, "base64")
+		}
+		sealed, err := decodeBytes(value, node.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		if len(sealed) < gcm.NonceSize()+gcm.Overhead() {
+			return nil, temporal.NewNonRetryableApplicationError(
+				"ciphertext is too short", "DecryptionFailed", nil)
+		}
+		plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				"ciphertext could not be decrypted with this key", "DecryptionFailed", err)
+		}
+		return &CryptoOutput{Value: string(plain)}, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown crypto operation %q", node.Operation), "InvalidData", nil)
+	}
+}
+
+// cryptoKey reads the key of a crypto credential. Its data holds key and an
+// optional encoding (utf8 by default, hex or base64).
+func (a *Activities) cryptoKey(ctx context.Context, ref string) ([]byte, error) {
+	cred, err := a.loadCredential(ctx, ref, models.CredentialTypeCrypto)
+	if err != nil {
+		return nil, err
+	}
+	key := credentialString(cred, "key")
+	if key == "" {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has no key", cred.Name), "InvalidCredential", nil)
+	}
+	switch encoding := credentialString(cred, "encoding"); encoding {
+	case "", "utf8":
+		return []byte(key), nil
+	case "hex", "base64":
+		b, err := decodeBytes(key, encoding)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has a key that is not valid %s", cred.Name, encoding), "InvalidCredential", err)
+		}
+		return b, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has unknown key encoding %q", cred.Name, encoding), "InvalidCredential", nil)
+	}
+}
+
+func hashFunc(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "md5":
+		return md5.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "", "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("unknown hash algorithm %q", algorithm), "InvalidData", nil)
+}
+
+func newGCM(key []byte, credential string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q key must be 16, 24 or 32 bytes for AES", credential), "InvalidCredential", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func encodeCryptoOutput(b []byte, encoding, fallback string) (*CryptoOutput, error) {
+	if encoding == "" {
+		encoding = fallback
+	}
+	switch encoding {
+	case "hex":
+		return &CryptoOutput{Value: hex.EncodeToString(b)}, nil
+	case "base64":
+		return &CryptoOutput{Value: base64.StdEncoding.EncodeToString(b)}, nil
+	case "base64url":
+		return &CryptoOutput{Value: base64.RawURLEncoding.EncodeToString(b)}, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("unknown encoding %q", encoding), "InvalidData", nil)
+}
+
+// decodeBytes reverses encodeCryptoOutput; base64 is the default and padded
+// or unpadded input is accepted
+func decodeBytes(s, encoding string) ([]byte, error) {
+	if encoding == "" {
+		encoding = "base64"
+	}
+	var b []byte
+	var err error
+	switch encoding {
+	case "hex":
+		b, err = hex.DecodeString(s)
+	case "base64":
+		if b, err = base64.StdEncoding.DecodeString(s); err != nil {
+			b, err = base64.RawStdEncoding.DecodeString(s)
+		}
+	case "base64url":
+		if b, err = base64.RawURLEncoding.DecodeString(s); err != nil {
+			b, err = base64.URLEncoding.DecodeString(s)
+		}
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown encoding %q", encoding), "InvalidData", nil)
+	}
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("value is not valid %s: %v", encoding, err), "InvalidData", err)
+	}
+	return b, nil
+}
+
+// randomString draws length characters uniformly from charset using
+// crypto/rand
+func randomString(length int, charset string) (string, error) {
+	if length == 0 {
+		length = 32
+	}
+	if charset == "" {
+		charset = "alphanumeric"
+	}
+	alphabet, ok := randomCharsets[charset]
+	if !ok {
+		return "", temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown charset %q", charset), "InvalidData", nil)
+	}
+	n := big.NewInt(int64(len(alphabet)))
+	out := make([]byte, length)
+	for i := range out {
+		idx, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		out[i] = alphabet[idx.Int64()]
+	}
+	return string(out), nil
+}
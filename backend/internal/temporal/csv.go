@@ -0,0 +1,213 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// CSVInput represents input for the CSV activity
+type CSVInput struct {
+	CSV   models.CSVNodeData `json:"csv"`
+	Input interface{}        `json:"input"`
+}
+
+// CSVOutput represents output from CSV generation
+type CSVOutput struct {
+	Text string            `json:"text,omitempty"`
+	File *models.BinaryRef `json:"file,omitempty"`
+	Rows int               `json:"rows"`
+}
+
+// CSVActivity parses CSV into an array of objects or generates CSV from an
+// array of objects, depending on the node's mode
+func (a *Activities) CSVActivity(ctx context.Context, input CSVInput) (interface{}, error) {
+	node := input.CSV
+	source := normalizeValue(input.Input)
+
+	delimiter := ','
+	if node.Delimiter != "" {
+		delimiter = []rune(node.Delimiter)[0]
+	}
+	headerRow := node.HeaderRow == nil || *node.HeaderRow
+
+	switch node.Mode {
+	case "parse":
+		data, err := a.contentAt(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		return parseCSV(data, node, delimiter, headerRow)
+	case "generate":
+		items, err := itemsAt(source, node.Path)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "InvalidData", err)
+		}
+		text, err := generateCSV(items, node.Columns, delimiter, headerRow)
+		if err != nil {
+			return nil, err
+		}
+		output := &CSVOutput{Rows: len(items)}
+		if node.Output != "file" {
+			output.Text = text
+			return output, nil
+		}
+		fileName := node.FileName
+		if fileName == "" {
+			fileName = "data.csv"
+		}
+		ref, err := storage.NewBinaryStore(a.DB).Put(ctx, fileName, "text/csv", []byte(text))
+		if err != nil {
+			return nil, err
+		}
+		output.File = ref
+		return output, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown csv mode %q", node.Mode), "InvalidData", nil)
+	}
+}
+
+func parseCSV(data []byte, node models.CSVNodeData, delimiter rune, headerRow bool) ([]interface{}, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM written by spreadsheet exports
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.LazyQuotes = node.LazyQuotes
+	r.TrimLeadingSpace = node.TrimLeadingSpace
+	r.FieldsPerRecord = -1 // ragged rows are padded or widened below
+	r.ReuseRecord = true
+
+	var columns []string
+	items := []interface{}{}
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid csv: %v", err), "InvalidData", err)
+		}
+		if line == 1 && headerRow {
+			columns = headerColumns(record)
+			continue
+		}
+		for len(columns) < len(record) {
+			columns = append(columns, "column"+strconv.Itoa(len(columns)+1))
+		}
+
+		item := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			var cell interface{}
+			if i < len(record) {
+				cell = record[i]
+				if node.InferTypes {
+					cell = inferCell(record[i])
+				}
+			} else if !node.InferTypes {
+				cell = ""
+			}
+			item[col] = cell
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// headerColumns names blank or repeated header cells so no value is lost
+func headerColumns(record []string) []string {
+	columns := make([]string, len(record))
+	seen := make(map[string]int, len(record))
+	for i, name := range record {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = "column" + strconv.Itoa(i+1)
+		}
+		if n := seen[name]; n > 0 {
+			seen[name] = n + 1
+			name = name + "_" + strconv.Itoa(n+1)
+		} else {
+			seen[name] = 1
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+// inferCell converts numbers and booleans; empty cells become null. Numbers
+// with leading zeros (IDs, postcodes) and non-finite values stay strings.
+func inferCell(s string) interface{} {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return nil
+	}
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	digits := strings.TrimPrefix(trimmed, "-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return s
+	}
+	// NaN and Inf parse as floats but cannot be encoded as JSON
+	if f, err := strconv.ParseFloat(trimmed, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	return s
+}
+
+func generateCSV(items []interface{}, columns []string, delimiter rune, headerRow bool) (string, error) {
+	if len(columns) == 0 {
+		keys := make(map[string]struct{})
+		for _, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				for k := range obj {
+					keys[k] = struct{}{}
+				}
+			}
+		}
+		for k := range keys {
+			columns = append(columns, k)
+		}
+		sort.Strings(columns)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = delimiter
+	if headerRow {
+		if err := w.Write(columns); err != nil {
+			return "", err
+		}
+	}
+	record := make([]string, len(columns))
+	for i, item := range items {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return "", temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("item %d is %s, expected an object", i, jsonTypeOf(item)), "InvalidData", nil)
+		}
+		for j, col := range columns {
+			v, _ := getPath(item, col)
+			record[j] = stringify(v)
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
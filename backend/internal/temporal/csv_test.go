@@ -0,0 +1,26 @@
+package temporal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		want interface{}
+	}{
+		{in: "", want: nil},
+		{in: "true", want: true},
+		{in: "12.5", want: 12.5},
+		{in: "007", want: "007"},
+		{in: "NaN", want: "NaN"},
+		{in: "Inf", want: "Inf"},
+		{in: "-Infinity", want: "-Infinity"},
+	}
+	for _, tt := range tests {
+		if got := inferCell(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("inferCell(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
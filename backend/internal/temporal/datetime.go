@@ -0,0 +1,350 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// DatetimeInput represents input for the date and time activity
+type DatetimeInput struct {
+	Datetime models.DatetimeNodeData `json:"datetime"`
+	Timezone string                  `json:"timezone,omitempty"` // workflow default zone
+	Input    interface{}             `json:"input"`
+}
+
+// DatetimeOutput represents output from the date and time activity. Unix and
+// Timezone are only set when the result is a date.
+type DatetimeOutput struct {
+	Value    interface{} `json:"value"`
+	Unix     *int64      `json:"unix,omitempty"`
+	Timezone string      `json:"timezone,omitempty"`
+}
+
+// namedLayouts may be used in place of a Go reference layout
+var namedLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"DateOnly":    time.DateOnly,
+	"DateTime":    time.DateTime,
+	"TimeOnly":    time.TimeOnly,
+	"Kitchen":     time.Kitchen,
+}
+
+// DatetimeActivity parses, shifts, converts and compares dates. Dates are
+// read and written in the node's timezone, falling back to the workflow's
+// and then UTC.
+func (a *Activities) DatetimeActivity(ctx context.Context, input DatetimeInput) (*DatetimeOutput, error) {
+	node := input.Datetime
+	source := normalizeValue(input.Input)
+
+	zone := node.Timezone
+	if zone == "" {
+		zone = input.Timezone
+	}
+	loc, err := loadZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parseDatetime(resolveTemplate(node.Value, source), node.Layout, loc)
+	if err != nil {
+		return nil, err
+	}
+	holidays := make(map[string]bool, len(node.Holidays))
+	for _, day := range node.Holidays {
+		holidays[day] = true
+	}
+	unit := models.DatetimeUnit(node.Unit)
+
+	switch node.Operation {
+	case models.DatetimeOpFormat:
+	case models.DatetimeOpConvert:
+		if loc, err = loadZone(node.ToTimezone); err != nil {
+			return nil, err
+		}
+		t = t.In(loc)
+	case models.DatetimeOpAdd:
+		t = shiftDatetime(t, node.Amount, unit)
+	case models.DatetimeOpSubtract:
+		t = shiftDatetime(t, -node.Amount, unit)
+	case models.DatetimeOpStartOf:
+		t = startOfPeriod(t, unit, node.WeekStart)
+	case models.DatetimeOpEndOf:
+		t = shiftDatetime(startOfPeriod(t, unit, node.WeekStart), 1, unit).Add(-time.Nanosecond)
+	case models.DatetimeOpAddBusinessDays:
+		t = addBusinessDays(t, node.Amount, holidays)
+	case models.DatetimeOpDiff, models.DatetimeOpBusinessDaysBetween:
+		other, err := parseDatetime(resolveTemplate(node.Other, source), node.Layout, loc)
+		if err != nil {
+			return nil, err
+		}
+		if node.Operation == models.DatetimeOpBusinessDaysBetween {
+			return &DatetimeOutput{Value: businessDaysBetween(t, other, holidays)}, nil
+		}
+		if unit == "" {
+			unit = "day"
+		}
+		return &DatetimeOutput{Value: diffDatetime(t, other, unit)}, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown datetime operation %q", node.Operation), "InvalidData", nil)
+	}
+
+	unix := t.Unix()
+	return &DatetimeOutput{
+		Value:    formatDatetime(t, node.Format),
+		Unix:     &unix,
+		Timezone: t.Location().String(),
+	}, nil
+}
+
+// loadZone resolves an IANA zone name; empty means UTC, never the worker's
+// local zone
+func loadZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown timezone %q", name), "InvalidData", err)
+	}
+	return loc, nil
+}
+
+// parseDatetime reads v with layout, or with the common formats and Unix
+// seconds when no layout is given. Values without an offset are read in
+// loc; null or empty means now.
+func parseDatetime(v interface{}, layout string, loc *time.Location) (time.Time, error) {
+	if v == nil {
+		return time.Now().In(loc), nil
+	}
+	s := strings.TrimSpace(stringify(v))
+	if s == "" {
+		return time.Now().In(loc), nil
+	}
+
+	switch layout {
+	case "":
+		for _, l := range timeLayouts {
+			if t, err := time.ParseInLocation(l, s, loc); err == nil {
+				return t.In(loc), nil
+			}
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return time.Unix(0, int64(f*float64(time.Second))).In(loc), nil
+		}
+	case "unix", "unixMilli":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			if layout == "unixMilli" {
+				return time.UnixMilli(int64(f)).In(loc), nil
+			}
+			return time.Unix(0, int64(f*float64(time.Second))).In(loc), nil
+		}
+	default:
+		if named, ok := namedLayouts[layout]; ok {
+			layout = named
+		}
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err != nil {
+			return time.Time{}, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("cannot parse date %q: %v", s, err), "InvalidData", err)
+		}
+		return t.In(loc), nil
+	}
+	return time.Time{}, temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("cannot parse date %q", s), "InvalidData", nil)
+}
+
+// formatDatetime writes t with a Go reference layout or a named one;
+// "unix" and "unixMilli" produce numbers
+func formatDatetime(t time.Time, format string) interface{} {
+	switch format {
+	case "":
+		return t.Format(time.RFC3339)
+	case "unix":
+		return t.Unix()
+	case "unixMilli":
+		return t.UnixMilli()
+	}
+	if named, ok := namedLayouts[format]; ok {
+		format = named
+	}
+	return t.Format(format)
+}
+
+// shiftDatetime moves t by n units. Calendar units keep the wall clock time
+// across DST changes and month arithmetic clamps to the end of the month
+// (Jan 31 + 1 month = Feb 28/29).
+func shiftDatetime(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "year":
+		return addMonths(t, 12*n)
+	case "quarter":
+		return addMonths(t, 3*n)
+	case "month":
+		return addMonths(t, n)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "hour":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "minute":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "second":
+		return t.Add(time.Duration(n) * time.Second)
+	case "millisecond":
+		return t.Add(time.Duration(n) * time.Millisecond)
+	}
+	return t
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// startOfPeriod truncates t to the beginning of its minute, hour, day, week,
+// month, quarter or year in t's zone. Weeks start on Monday unless
+// weekStart is "sunday".
+func startOfPeriod(t time.Time, unit, weekStart string) time.Time {
+	y, m, d := t.Date()
+	loc := t.Location()
+	switch unit {
+	case "minute":
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, loc)
+	case "hour":
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, loc)
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	case "week":
+		offset := int(t.Weekday()) - int(time.Monday)
+		if weekStart == "sunday" {
+			offset = int(t.Weekday())
+		}
+		if offset < 0 {
+			offset += 7
+		}
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case "quarter":
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, loc)
+	case "year":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	return t
+}
+
+// diffDatetime returns other - t in unit. Months, quarters and years count
+// whole calendar periods; other units may be fractional.
+func diffDatetime(t, other time.Time, unit string) float64 {
+	switch unit {
+	case "year", "quarter", "month":
+		months := monthsBetween(t, other)
+		switch unit {
+		case "year":
+			return float64(months / 12)
+		case "quarter":
+			return float64(months / 3)
+		}
+		return float64(months)
+	}
+
+	d := other.Sub(t)
+	switch unit {
+	case "week":
+		return d.Hours() / (24 * 7)
+	case "day":
+		return d.Hours() / 24
+	case "hour":
+		return d.Hours()
+	case "minute":
+		return d.Minutes()
+	case "second":
+		return d.Seconds()
+	}
+	return float64(d.Milliseconds())
+}
+
+// monthsBetween counts whole months from t to other, truncated toward zero
+func monthsBetween(t, other time.Time) int {
+	other = other.In(t.Location())
+	months := (other.Year()-t.Year())*12 + int(other.Month()-t.Month())
+	if months > 0 && addMonths(t, months).After(other) {
+		months--
+	} else if months < 0 && addMonths(t, months).Before(other) {
+		months++
+	}
+	return months
+}
+
+func isBusinessDay(t time.Time, holidays map[string]bool) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	return !holidays[t.Format(time.DateOnly)]
+}
+
+// addBusinessDays moves t by n working days, skipping weekends and holidays;
+// negative n moves backwards
+func addBusinessDays(t time.Time, n int, holidays map[string]bool) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if isBusinessDay(t, holidays) {
+			n--
+		}
+	}
+	return t
+}
+
+// businessDaysBetween counts working days from t's date up to but excluding
+// other's date; the count is negative when other is earlier
+func businessDaysBetween(t, other time.Time, holidays map[string]bool) int {
+	loc := t.Location()
+	from := startOfPeriod(t, "day", "")
+	to := startOfPeriod(other.In(loc), "day", "")
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	// Whole weeks hold five weekdays; walk the days left over and the holidays
+	days := int(math.Round(to.Sub(from).Hours() / 24))
+	count := days / 7 * 5
+	for d := from.AddDate(0, 0, days/7*7); d.Before(to); d = d.AddDate(0, 0, 1) {
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			count++
+		}
+	}
+	for day := range holidays {
+		h, err := time.ParseInLocation(time.DateOnly, day, loc)
+		if err != nil || h.Before(from) || !h.Before(to) {
+			continue
+		}
+		if wd := h.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			count--
+		}
+	}
+	return sign * count
+}
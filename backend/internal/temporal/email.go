@@ -0,0 +1,426 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-sasl"
+	"github.com/emersion/go-smtp"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// SendEmailInput represents input for the send email activity
+type SendEmailInput struct {
+	Email models.EmailNodeData `json:"email"`
+	Input interface{}          `json:"input"`
+}
+
+// SendEmailOutput represents output from the send email activity
+type SendEmailOutput struct {
+	MessageID  string   `json:"messageId"`  // Message-ID header of the sent message
+	Response   string   `json:"response"`   // server reply to DATA, usually with its queue ID
+	Recipients []string `json:"recipients"` // envelope recipients
+}
+
+// emailAttachment is a file loaded from binary storage
+type emailAttachment struct {
+	ref  *models.BinaryRef
+	data []byte
+}
+
+// SendEmailActivity sends a message through the SMTP server of the node's
+// credential. 4xx replies are returned as retryable errors, 5xx replies as
+// non-retryable ones.
+func (a *Activities) SendEmailActivity(ctx context.Context, input SendEmailInput) (*SendEmailOutput, error) {
+	node := input.Email
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeSMTP)
+	if err != nil {
+		return nil, err
+	}
+	source := normalizeValue(input.Input)
+
+	fromValue := stringify(resolveTemplate(node.From, source))
+	if fromValue == "" {
+		fromValue = credentialString(cred, "from")
+	}
+	from, err := mail.ParseAddress(fromValue)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid from address %q", fromValue), "InvalidAddress", err)
+	}
+	to, err := resolveAddresses(node.To, source)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := resolveAddresses(node.Cc, source)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := resolveAddresses(node.Bcc, source)
+	if err != nil {
+		return nil, err
+	}
+	var replyTo []*mail.Address
+	if node.ReplyTo != "" {
+		if replyTo, err = resolveAddresses([]string{node.ReplyTo}, source); err != nil {
+			return nil, err
+		}
+	}
+
+	var attachments []emailAttachment
+	store := storage.NewBinaryStore(a.DB)
+	for _, path := range node.Attachments {
+		refs, err := binaryRefsAt(source, path)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			stored, data, err := store.Get(ctx, ref.BinaryID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load attachment %s: %w", ref.BinaryID, err)
+			}
+			attachments = append(attachments, emailAttachment{ref: stored, data: data})
+		}
+	}
+
+	messageID := newMessageID(ctx, from.Address)
+	msg, err := buildEmailMessage(emailHeaders{
+		from:      from,
+		to:        to,
+		cc:        cc,
+		replyTo:   replyTo,
+		subject:   stringify(resolveTemplate(node.Subject, source)),
+		messageID: messageID,
+	}, stringify(resolveTemplate(node.Text, source)), stringify(resolveTemplate(node.HTML, source)), attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []string
+	for _, list := range [][]*mail.Address{to, cc, bcc} {
+		for _, addr := range list {
+			recipients = append(recipients, addr.Address)
+		}
+	}
+
+	response, err := deliverEmail(ctx, cred, from.Address, recipients, msg)
+	if err != nil {
+		return nil, classifySMTPError(err)
+	}
+	return &SendEmailOutput{MessageID: messageID, Response: response, Recipients: recipients}, nil
+}
+
+// resolveAddresses expands templated entries, each of which may hold a
+// comma-separated list or resolve to an array of addresses
+func resolveAddresses(entries []string, source interface{}) ([]*mail.Address, error) {
+	var out []*mail.Address
+	for _, entry := range entries {
+		var values []string
+		switch v := resolveTemplate(entry, source).(type) {
+		case []interface{}:
+			for _, item := range v {
+				values = append(values, stringify(item))
+			}
+		default:
+			values = append(values, stringify(v))
+		}
+		for _, value := range values {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			list, err := mail.ParseAddressList(value)
+			if err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("invalid address %q", value), "InvalidAddress", err)
+			}
+			out = append(out, list...)
+		}
+	}
+	return out, nil
+}
+
+// newMessageID derives the Message-ID from the workflow run and activity, so
+// every attempt at sending the same message uses the same ID and receivers
+// can drop a copy delivered by a retry. Outside an activity it is random.
+func newMessageID(ctx context.Context, fromAddress string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(fromAddress, "@"); at >= 0 {
+		domain = fromAddress[at+1:]
+	}
+	b := make([]byte, 16)
+	if activity.IsActivity(ctx) {
+		info := activity.GetInfo(ctx)
+		sum := sha256.Sum256([]byte(info.WorkflowExecution.RunID + "/" + info.ActivityID))
+		copy(b, sum[:])
+	} else {
+		_, _ = rand.Read(b)
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
+
+type emailHeaders struct {
+	from      *mail.Address
+	to        []*mail.Address
+	cc        []*mail.Address
+	replyTo   []*mail.Address
+	subject   string
+	messageID string
+}
+
+// buildEmailMessage renders an RFC 5322 message. Bcc recipients are only
+// part of the envelope.
+func buildEmailMessage(h emailHeaders, text, html string, attachments []emailAttachment) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+	joinAddresses := func(list []*mail.Address) string {
+		parts := make([]string, len(list))
+		for i, addr := range list {
+			parts[i] = addr.String()
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	header("From", h.from.String())
+	if len(h.to) > 0 {
+		header("To", joinAddresses(h.to))
+	}
+	if len(h.cc) > 0 {
+		header("Cc", joinAddresses(h.cc))
+	}
+	if len(h.replyTo) > 0 {
+		header("Reply-To", joinAddresses(h.replyTo))
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", h.subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", h.messageID)
+	header("MIME-Version", "1.0")
+
+	bodyHeader, body, err := emailBody(text, html)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		for _, name := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+			if v := bodyHeader.Get(name); v != "" {
+				header(name, v)
+			}
+		}
+		buf.WriteString("\r\n")
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	header("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()}))
+	buf.WriteString("\r\n")
+
+	bodyPart, err := mixed.CreatePart(bodyHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := bodyPart.Write(body); err != nil {
+		return nil, err
+	}
+
+	for _, att := range attachments {
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {att.ref.MimeType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": att.ref.FileName})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64Lines(part, att.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// emailBody renders the text and/or HTML versions of the message, using
+// multipart/alternative when both are present
+func emailBody(text, html string) (textproto.MIMEHeader, []byte, error) {
+	encode := func(body string) ([]byte, error) {
+		var buf bytes.Buffer
+		qp := quotedprintable.NewWriter(&buf)
+		if _, err := qp.Write([]byte(body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	if text == "" || html == "" {
+		contentType, body := "text/plain; charset=utf-8", text
+		if html != "" {
+			contentType, body = "text/html; charset=utf-8", html
+		}
+		encoded, err := encode(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		}, encoded, nil
+	}
+
+	var buf bytes.Buffer
+	alt := multipart.NewWriter(&buf)
+	for _, version := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		part, err := alt.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {version.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		encoded, err := encode(version.body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := part.Write(encoded); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := alt.Close(); err != nil {
+		return nil, nil, err
+	}
+	return textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alt.Boundary()})},
+	}, buf.Bytes(), nil
+}
+
+// writeBase64Lines encodes data as base64 wrapped at 76 characters
+func writeBase64Lines(w interface{ Write([]byte) (int, error) }, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := w.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := w.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+// deliverEmail hands the message to the credential's SMTP server and returns
+// the server's reply to DATA. The credential holds host, port, username,
+// password and security (starttls, tls or none).
+func deliverEmail(ctx context.Context, cred *models.Credential, from string, recipients []string, msg []byte) (string, error) {
+	host := credentialString(cred, "host")
+	if host == "" {
+		return "", temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has no host", cred.Name), "InvalidCredential", nil)
+	}
+	port := credentialString(cred, "port")
+	security := credentialString(cred, "security")
+	if port == "" {
+		port = "587"
+		if security == "tls" {
+			port = "465"
+		}
+	}
+	if security == "" {
+		security = "starttls"
+		if port == "465" {
+			security = "tls"
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return "", err
+	}
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+
+	var c *smtp.Client
+	switch security {
+	case "tls":
+		c = smtp.NewClient(tls.Client(conn, tlsConfig))
+	case "starttls":
+		if c, err = smtp.NewClientStartTLS(conn, tlsConfig); err != nil {
+			conn.Close()
+			return "", err
+		}
+	case "none":
+		c = smtp.NewClient(conn)
+	default:
+		conn.Close()
+		return "", temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has unknown security %q", cred.Name, security), "InvalidCredential", nil)
+	}
+	defer c.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		c.CommandTimeout = time.Until(deadline)
+		c.SubmissionTimeout = time.Until(deadline)
+	}
+
+	if user := credentialString(cred, "username"); user != "" {
+		if err := c.Auth(sasl.NewPlainClient("", user, credentialString(cred, "password"))); err != nil {
+			return "", err
+		}
+	}
+	if err := c.Mail(from, nil); err != nil {
+		return "", err
+	}
+	for _, rcpt := range recipients {
+		if err := c.Rcpt(rcpt, nil); err != nil {
+			return "", err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return "", err
+	}
+	resp, err := w.CloseWithResponse()
+	if err != nil {
+		return "", err
+	}
+	_ = c.Quit()
+	return resp.StatusText, nil
+}
+
+// classifySMTPError leaves transient 4xx replies retryable and marks
+// permanent 5xx replies as non-retryable
+func classifySMTPError(err error) error {
+	var smtpErr *smtp.SMTPError
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return temporal.NewNonRetryableApplicationError(smtpErr.Error(), "SMTPPermanentError", err)
+	}
+	return err
+}
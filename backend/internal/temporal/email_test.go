@@ -0,0 +1,135 @@
+package temporal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/mail"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/emersion/go-smtp"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// smtpSink is an in-process SMTP server that keeps every message it is sent.
+// The first failFirst messages are answered with a 451 after being read.
+type smtpSink struct {
+	failFirst int
+
+	mu       sync.Mutex
+	messages []*mail.Message
+}
+
+func (s *smtpSink) NewSession(*smtp.Conn) (smtp.Session, error) {
+	return &smtpSinkSession{sink: s}, nil
+}
+
+type smtpSinkSession struct {
+	sink *smtpSink
+}
+
+func (s *smtpSinkSession) Reset()        {}
+func (s *smtpSinkSession) Logout() error { return nil }
+
+func (s *smtpSinkSession) Mail(string, *smtp.MailOptions) error { return nil }
+
+func (s *smtpSinkSession) Rcpt(string, *smtp.RcptOptions) error { return nil }
+
+func (s *smtpSinkSession) Data(r io.Reader) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	s.sink.mu.Lock()
+	defer s.sink.mu.Unlock()
+	s.sink.messages = append(s.sink.messages, msg)
+	if len(s.sink.messages) <= s.sink.failFirst {
+		return &smtp.SMTPError{Code: 451, EnhancedCode: smtp.EnhancedCode{4, 3, 0}, Message: "Try again later"}
+	}
+	return nil
+}
+
+// startSMTPSink serves sink on a loopback port and returns the port
+func startSMTPSink(t *testing.T, sink *smtpSink) string {
+	t.Helper()
+	server := smtp.NewServer(sink)
+	server.Domain = "localhost"
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(ln)
+	t.Cleanup(func() { server.Close() })
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	return port
+}
+
+func expectSMTPCredential(mock sqlmock.Sqlmock, port string) {
+	data, _ := json.Marshal(map[string]string{"host": "127.0.0.1", "port": port, "security": "none"})
+	now := time.Now()
+	mock.ExpectQuery("FROM credentials").WithArgs("mail").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "type", "data", "created_at", "updated_at"}).
+			AddRow("cred-1", "mail", models.CredentialTypeSMTP, data, now, now))
+}
+
+func TestSendEmailRetryKeepsMessageID(t *testing.T) {
+	sink := &smtpSink{failFirst: 1}
+	port := startSMTPSink(t, sink)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	expectSMTPCredential(mock, port)
+	expectSMTPCredential(mock, port)
+
+	dagStruct := models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "notify", Type: "email", Data: map[string]interface{}{
+				"credential": "mail",
+				"from":       "Workflows <bot@example.com>",
+				"to":         []interface{}{"{{ payload.email }}"},
+				"subject":    "Order {{ payload.id }}",
+				"text":       "Your order has shipped",
+			}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "notify"},
+			{ID: "e2", Source: "notify", Target: "out"},
+		},
+	}
+	env, _ := newWorkflowTestEnv(t, dagStruct)
+	env.RegisterActivity((&Activities{DB: db}).SendEmailActivity)
+
+	payload := map[string]interface{}{"email": "ada@example.com", "id": "42"}
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec", Payload: payload})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sink.messages) != 2 {
+		t.Fatalf("sink got %d messages, want the 451 and the retry", len(sink.messages))
+	}
+	first, second := sink.messages[0].Header.Get("Message-ID"), sink.messages[1].Header.Get("Message-ID")
+	if first == "" || first != second {
+		t.Errorf("Message-IDs %q and %q, want the same ID on every attempt", first, second)
+	}
+	if got := sink.messages[1].Header.Get("Subject"); got != "Order 42" {
+		t.Errorf("subject = %q, want Order 42", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,180 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// maxExecOutputBytes caps how much of stdout and stderr is kept
+const maxExecOutputBytes = 10 << 20
+
+// ExecInput represents input for the execute command activity
+type ExecInput struct {
+	Exec  models.ExecNodeData `json:"exec"`
+	Input interface{}         `json:"input"`
+}
+
+// ExecOutput represents output from the execute command activity
+type ExecOutput struct {
+	ExitCode  int         `json:"exitCode"`
+	Stdout    string      `json:"stdout"`
+	Stderr    string      `json:"stderr"`
+	Data      interface{} `json:"data,omitempty"` // stdout parsed as JSON
+	Truncated bool        `json:"truncated,omitempty"`
+	Duration  int64       `json:"durationMs"`
+}
+
+// ExecActivity runs an allowlisted command without a shell in a fresh
+// temporary directory. The command sees PATH, the worker variables named in
+// ExecEnv and the node's env entries, which must also be named there.
+func (a *Activities) ExecActivity(ctx context.Context, input ExecInput) (*ExecOutput, error) {
+	node := input.Exec
+	source := normalizeValue(input.Input)
+
+	if len(a.ExecCommands) == 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"exec nodes are disabled on this worker; set EXEC_ALLOWED_COMMANDS to enable them", "ExecDisabled", nil)
+	}
+	if !slices.Contains(a.ExecCommands, node.Command) {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("command %q is not allowed on this worker", node.Command), "CommandNotAllowed", nil)
+	}
+	path, err := exec.LookPath(node.Command)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("command %q not found on this worker", node.Command), "CommandNotFound", err)
+	}
+
+	env, err := a.execEnv(node.Env, source)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, len(node.Args))
+	for i, arg := range node.Args {
+		args[i] = stringify(resolveTemplate(arg, source))
+	}
+	var stdin []byte
+	if node.Stdin != "" {
+		if stdin, _, err = a.contentWithType(ctx, source, node.Stdin); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := models.DefaultExecTimeout
+	if node.Timeout != "" {
+		if timeout, err = time.ParseDuration(node.Timeout); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid timeout %q", node.Timeout), "InvalidData", err)
+		}
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "exec-node-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	stdout := &cappedBuffer{limit: maxExecOutputBytes}
+	stderr := &cappedBuffer{limit: maxExecOutputBytes}
+	cmd := exec.CommandContext(runCtx, path, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Children that keep the pipes open must not hold up the activity
+	cmd.WaitDelay = 5 * time.Second
+
+	start := time.Now()
+	err = cmd.Run()
+	output := &ExecOutput{
+		ExitCode:  cmd.ProcessState.ExitCode(),
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+		Duration:  time.Since(start).Milliseconds(),
+	}
+
+	if runCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%s timed out after %s", filepath.Base(path), timeout), "ExecTimeout", err)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run %s: %w", filepath.Base(path), err)
+	}
+	if output.ExitCode != 0 && !node.IgnoreExitCode {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%s exited with code %d: %s", filepath.Base(path), output.ExitCode, lastLine(output.Stderr)), "ExecFailed", err)
+	}
+
+	if node.ParseJSON {
+		if err := json.Unmarshal([]byte(output.Stdout), &output.Data); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("stdout is not valid JSON: %v", err), "InvalidData", err)
+		}
+	}
+	return output, nil
+}
+
+// execEnv builds the command environment: PATH, the allowed worker variables,
+// then the node's entries
+func (a *Activities) execEnv(nodeEnv map[string]string, source interface{}) ([]string, error) {
+	env := []string{"PATH=" + os.Getenv("PATH")}
+	for _, name := range a.ExecEnv {
+		if _, set := nodeEnv[name]; set {
+			continue
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	for name, value := range nodeEnv {
+		if !slices.Contains(a.ExecEnv, name) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("environment variable %q is not allowed on this worker", name), "EnvNotAllowed", nil)
+		}
+		env = append(env, name+"="+stringify(resolveTemplate(value, source)))
+	}
+	return env, nil
+}
+
+// lastLine returns the last non-empty line of s, which for most tools holds
+// the error message
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// cappedBuffer keeps the first limit bytes written to it and discards the rest
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,122 @@
+package temporal
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestExecActivityAllowlist(t *testing.T) {
+	node := models.ExecNodeData{Command: "cat"}
+	if _, err := (&Activities{}).ExecActivity(context.Background(), ExecInput{Exec: node}); !isNonRetryable(err) || !strings.Contains(err.Error(), "disabled") {
+		t.Errorf("no allowlist: got %v, want exec disabled", err)
+	}
+
+	a := &Activities{ExecCommands: []string{"env"}}
+	for _, command := range []string{"cat", "/usr/bin/env", "env "} {
+		node.Command = command
+		if _, err := a.ExecActivity(context.Background(), ExecInput{Exec: node}); !isNonRetryable(err) || !strings.Contains(err.Error(), "not allowed") {
+			t.Errorf("%q: got %v, want a command not allowed error", command, err)
+		}
+	}
+}
+
+func TestExecActivityEnvironment(t *testing.T) {
+	t.Setenv("EXEC_TEST_SHARED", "worker")
+	t.Setenv("EXEC_TEST_OVERRIDDEN", "worker")
+	t.Setenv("EXEC_TEST_SECRET", "hidden")
+	a := &Activities{
+		ExecCommands: []string{"env"},
+		ExecEnv:      []string{"EXEC_TEST_SHARED", "EXEC_TEST_OVERRIDDEN", "EXEC_TEST_GREETING"},
+	}
+
+	out, err := a.ExecActivity(context.Background(), ExecInput{
+		Exec: models.ExecNodeData{Command: "env", Env: map[string]string{
+			"EXEC_TEST_OVERRIDDEN": "node",
+			"EXEC_TEST_GREETING":   "hello {{ name }}",
+		}},
+		Input: map[string]interface{}{"name": "ada"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(out.Stdout), "\n") {
+		if !strings.HasPrefix(line, "PATH=") {
+			got = append(got, line)
+		}
+	}
+	want := map[string]bool{
+		"EXEC_TEST_SHARED=worker":      true,
+		"EXEC_TEST_OVERRIDDEN=node":    true,
+		"EXEC_TEST_GREETING=hello ada": true,
+	}
+	if len(got) != len(want) {
+		t.Errorf("environment = %q, want PATH plus %d allowed variables", got, len(want))
+	}
+	for _, line := range got {
+		if !want[line] {
+			t.Errorf("unexpected variable %q", line)
+		}
+	}
+
+	_, err = a.ExecActivity(context.Background(), ExecInput{
+		Exec: models.ExecNodeData{Command: "env", Env: map[string]string{"EXEC_TEST_SECRET": "x"}},
+	})
+	if !isNonRetryable(err) || !strings.Contains(err.Error(), "EXEC_TEST_SECRET") {
+		t.Errorf("got %v, want the unlisted variable rejected", err)
+	}
+}
+
+func TestExecActivityStdinAndJSON(t *testing.T) {
+	a := &Activities{ExecCommands: []string{"cat", "pwd"}}
+	out, err := a.ExecActivity(context.Background(), ExecInput{
+		Exec:  models.ExecNodeData{Command: "cat", Stdin: "body", ParseJSON: true},
+		Input: map[string]interface{}{"body": `{"ok":true}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"ok": true}; !reflect.DeepEqual(out.Data, want) {
+		t.Errorf("data = %v, want %v", out.Data, want)
+	}
+
+	// Commands run in a fresh directory, not the worker's
+	out, err = a.ExecActivity(context.Background(), ExecInput{Exec: models.ExecNodeData{Command: "pwd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := strings.TrimSpace(out.Stdout)
+	if wd, _ := os.Getwd(); dir == wd || !strings.Contains(dir, "exec-node-") {
+		t.Errorf("ran in %q, want a temporary directory", dir)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s still exists after the command, err = %v", dir, err)
+	}
+}
+
+func TestExecActivityExitCodeAndTimeout(t *testing.T) {
+	a := &Activities{ExecCommands: []string{"sh", "sleep"}}
+	node := models.ExecNodeData{Command: "sh", Args: []string{"-c", "echo first >&2; echo broken >&2; exit 3"}}
+	_, err := a.ExecActivity(context.Background(), ExecInput{Exec: node})
+	if !isNonRetryable(err) || !strings.Contains(err.Error(), "code 3: broken") {
+		t.Errorf("got %v, want the exit code with the last stderr line", err)
+	}
+
+	node.IgnoreExitCode = true
+	out, err := a.ExecActivity(context.Background(), ExecInput{Exec: node})
+	if err != nil || out.ExitCode != 3 {
+		t.Errorf("ignoreExitCode: got %+v, %v, want exit code 3 in the output", out, err)
+	}
+
+	_, err = a.ExecActivity(context.Background(), ExecInput{
+		Exec: models.ExecNodeData{Command: "sleep", Args: []string{"5"}, Timeout: "100ms"},
+	})
+	if !isNonRetryable(err) || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("got %v, want a timeout", err)
+	}
+}
@@ -0,0 +1,157 @@
+package temporal
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// placeholderPattern matches {{ path }} references into a node's input
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// normalizeValue converts typed Go values (e.g. activity output structs) into
+// the generic map/slice form that paths can walk
+func normalizeValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, map[string]interface{}, []interface{}, string, float64, bool:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// splitPath turns "items[0].name" or "items.0.name" into its segments
+func splitPath(path string) []string {
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+	var parts []string
+	for _, p := range strings.Split(path, ".") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// getPath reads a dot-path from a decoded JSON value. An empty path returns
+// the value itself.
+func getPath(value interface{}, path string) (interface{}, bool) {
+	current := normalizeValue(value)
+	for _, part := range splitPath(path) {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			idx, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, false
+			}
+			if idx < 0 {
+				idx += len(node)
+			}
+			if idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			current = node[idx]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath writes value at a dot-path, creating intermediate objects
+func setPath(target map[string]interface{}, path string, value interface{}) error {
+	parts := splitPath(path)
+	if len(parts) == 0 {
+		return fmt.Errorf("empty path")
+	}
+
+	current := target
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			if existing, exists := current[part]; exists && existing != nil {
+				return fmt.Errorf("cannot set %q: %q is not an object", path, part)
+			}
+			next = make(map[string]interface{})
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// resolveTemplate substitutes {{ path }} references in s with values from
+// input. A string that is exactly one placeholder yields the referenced value
+// with its original type; otherwise the values are interpolated as text.
+func resolveTemplate(s string, input interface{}) interface{} {
+	if m := placeholderPattern.FindStringSubmatchIndex(s); m != nil && m[0] == 0 && m[1] == len(s) {
+		v, _ := getPath(input, s[m[2]:m[3]])
+		return v
+	}
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		path := placeholderPattern.FindStringSubmatch(match)[1]
+		v, ok := getPath(input, path)
+		if !ok || v == nil {
+			return ""
+		}
+		return stringify(v)
+	})
+}
+
+// resolveValue applies resolveTemplate to every string inside v
+func resolveValue(v interface{}, input interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return resolveTemplate(val, input)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = resolveValue(item, input)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = resolveValue(item, input)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// stringify renders scalars naturally and everything else as JSON
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(b)
+	}
+}
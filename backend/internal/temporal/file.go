@@ -0,0 +1,277 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+	"github.com/your-org/n8n-clone/pkg/fsutil"
+)
+
+// maxFileReadBytes caps files read into a workflow
+const maxFileReadBytes = 100 << 20
+
+// FileInput represents input for the local file activity
+type FileInput struct {
+	File  models.FileNodeData `json:"file"`
+	Input interface{}         `json:"input"`
+}
+
+// FileOutput represents output from the local file activity. Paths are
+// relative to the worker's file root.
+type FileOutput struct {
+	Path        string            `json:"path"`
+	Size        int64             `json:"size,omitempty"`
+	Text        *string           `json:"text,omitempty"` // read with text output
+	File        *models.BinaryRef `json:"file,omitempty"` // read with file output
+	Entries     []FileEntry       `json:"entries,omitempty"`
+	Destination string            `json:"destination,omitempty"` // move
+	Deleted     bool              `json:"deleted,omitempty"`
+}
+
+// FileEntry describes one listed file or directory
+type FileEntry struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Size       int64     `json:"size"`
+	IsDir      bool      `json:"isDir"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// FileActivity reads, writes, appends, lists, moves or deletes files below
+// the worker's FileRoot. Paths that leave the root, directly or through a
+// symlink, are rejected.
+func (a *Activities) FileActivity(ctx context.Context, input FileInput) (*FileOutput, error) {
+	node := input.File
+	source := normalizeValue(input.Input)
+
+	if a.FileRoot == "" {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"file nodes are disabled on this worker; set FILE_NODE_ROOT to enable them", "FileNodeDisabled", nil)
+	}
+	rel := stringify(resolveTemplate(node.File, source))
+	full, err := a.resolveFilePath(rel)
+	if err != nil {
+		return nil, err
+	}
+	output := &FileOutput{Path: a.relativeFilePath(full)}
+
+	switch node.Operation {
+	case models.FileOpRead:
+		info, err := os.Stat(full)
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		if info.IsDir() {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("%q is a directory", rel), "InvalidData", nil)
+		}
+		if info.Size() > maxFileReadBytes {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("file is %d bytes, larger than the %d byte limit", info.Size(), maxFileReadBytes), "InvalidData", nil)
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		output.Size = int64(len(data))
+		if node.Output == "file" {
+			ref, err := storage.NewBinaryStore(a.DB).Put(ctx, filepath.Base(full), "", data)
+			if err != nil {
+				return nil, err
+			}
+			output.File = ref
+			break
+		}
+		if !utf8.Valid(data) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("%q is not valid UTF-8 text; use file output for binary data", rel), "InvalidData", nil)
+		}
+		text := string(data)
+		output.Text = &text
+	case models.FileOpWrite, models.FileOpAppend:
+		data, _, err := a.contentWithType(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return nil, classifyFileError(err)
+		}
+		flags := os.O_WRONLY | os.O_CREATE | fsutil.ONoFollow
+		switch {
+		case node.Operation == models.FileOpAppend:
+			flags |= os.O_APPEND
+		case node.Overwrite:
+			flags |= os.O_TRUNC
+		default:
+			flags |= os.O_EXCL
+		}
+		f, err := os.OpenFile(full, flags, 0o644)
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return nil, classifyFileError(err)
+		}
+		if err := f.Close(); err != nil {
+			return nil, classifyFileError(err)
+		}
+		info, err := os.Stat(full)
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		output.Size = info.Size()
+	case models.FileOpList:
+		entries, err := a.listFiles(full, node.Pattern, node.Recursive)
+		if err != nil {
+			return nil, err
+		}
+		output.Entries = entries
+	case models.FileOpMove:
+		dest, err := a.resolveFilePath(stringify(resolveTemplate(node.Destination, source)))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Lstat(full); err != nil {
+			return nil, classifyFileError(err)
+		}
+		if !node.Overwrite {
+			if _, err := os.Lstat(dest); err == nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("%q already exists", a.relativeFilePath(dest)), "FileExists", nil)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return nil, classifyFileError(err)
+		}
+		if err := os.Rename(full, dest); err != nil {
+			return nil, classifyFileError(err)
+		}
+		output.Destination = a.relativeFilePath(dest)
+	case models.FileOpDelete:
+		if full == a.fileRootPath() {
+			return nil, temporal.NewNonRetryableApplicationError(
+				"refusing to delete the file root", "InvalidData", nil)
+		}
+		info, err := os.Lstat(full)
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		if info.IsDir() && node.Recursive {
+			err = os.RemoveAll(full)
+		} else {
+			err = os.Remove(full)
+		}
+		if err != nil {
+			return nil, classifyFileError(err)
+		}
+		output.Deleted = true
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown file operation %q", node.Operation), "InvalidData", nil)
+	}
+	return output, nil
+}
+
+// resolveFilePath maps a node path onto the file root
+func (a *Activities) resolveFilePath(p string) (string, error) {
+	full, err := fsutil.ResolvePath(a.FileRoot, p)
+	if err != nil {
+		return "", temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid path %q: %v", p, err), "InvalidPath", err)
+	}
+	return full, nil
+}
+
+// fileRootPath is the root as ResolvePath sees it, with symlinks resolved
+func (a *Activities) fileRootPath() string {
+	root, _ := fsutil.ResolvePath(a.FileRoot, "")
+	return root
+}
+
+// relativeFilePath reports full relative to the root using forward slashes
+func (a *Activities) relativeFilePath(full string) string {
+	rel, err := filepath.Rel(a.fileRootPath(), full)
+	if err != nil {
+		return full
+	}
+	return filepath.ToSlash(rel)
+}
+
+// listFiles lists dir, descending into subdirectories when recursive.
+// Pattern is matched against entry names.
+func (a *Activities) listFiles(dir, pattern string, recursive bool) ([]FileEntry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, classifyFileError(err)
+	}
+	if !info.IsDir() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%q is not a directory", a.relativeFilePath(dir)), "InvalidData", nil)
+	}
+
+	entries := []FileEntry{}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		if pattern == "" || matchName(pattern, d.Name()) {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			entries = append(entries, FileEntry{
+				Name:       d.Name(),
+				Path:       a.relativeFilePath(p),
+				Size:       info.Size(),
+				IsDir:      d.IsDir(),
+				ModifiedAt: info.ModTime().UTC(),
+			})
+		}
+		if d.IsDir() && !recursive {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyFileError(err)
+	}
+	return entries, nil
+}
+
+func matchName(pattern, name string) bool {
+	ok, _ := filepath.Match(pattern, name)
+	return ok
+}
+
+// classifyFileError marks missing files, existing targets and permission
+// errors as non-retryable
+func classifyFileError(err error) error {
+	var pathErr *fs.PathError
+	msg := err.Error()
+	if errors.As(err, &pathErr) {
+		msg = fmt.Sprintf("%s: %v", filepath.Base(pathErr.Path), pathErr.Err)
+	}
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return temporal.NewNonRetryableApplicationError(msg, "FileNotFound", err)
+	case errors.Is(err, fs.ErrExist):
+		return temporal.NewNonRetryableApplicationError(msg, "FileExists", err)
+	case errors.Is(err, fs.ErrPermission):
+		return temporal.NewNonRetryableApplicationError(msg, "PermissionDenied", err)
+	}
+	return err
+}
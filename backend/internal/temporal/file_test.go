@@ -0,0 +1,103 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestFileActivityRejectsSymlinkEscapes(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "pwned.txt")
+	if err := os.Symlink(target, filepath.Join(root, "report.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "exports")); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Activities{FileRoot: root}
+	input := map[string]interface{}{"text": "hello"}
+	for _, node := range []models.FileNodeData{
+		{Operation: models.FileOpWrite, File: "report.txt", Path: "text", Overwrite: true},
+		{Operation: models.FileOpAppend, File: "report.txt", Path: "text"},
+		{Operation: models.FileOpWrite, File: "exports/report.txt", Path: "text"},
+		{Operation: models.FileOpWrite, File: "../report.txt", Path: "text"},
+		{Operation: models.FileOpRead, File: "exports/report.txt"},
+	} {
+		if _, err := a.FileActivity(context.Background(), FileInput{File: node, Input: input}); err == nil {
+			t.Errorf("%s %q succeeded", node.Operation, node.File)
+		}
+	}
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Errorf("file outside the root was created: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(outside, "report.txt")); !os.IsNotExist(err) {
+		t.Errorf("file outside the root was created: %v", err)
+	}
+}
+
+func TestFileActivityWritesInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	a := &Activities{FileRoot: root}
+	input := map[string]interface{}{"text": "hello\n"}
+
+	for _, op := range []string{models.FileOpWrite, models.FileOpAppend} {
+		node := models.FileNodeData{Operation: op, File: "out/log.txt", Path: "text"}
+		if _, err := a.FileActivity(context.Background(), FileInput{File: node, Input: input}); err != nil {
+			t.Fatalf("%s: %v", op, err)
+		}
+	}
+	out, err := a.FileActivity(context.Background(), FileInput{
+		File: models.FileNodeData{Operation: models.FileOpRead, File: "out/log.txt"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Path != "out/log.txt" || out.Text == nil || *out.Text != "hello\nhello\n" {
+		t.Errorf("read = %+v", out)
+	}
+}
+
+func TestFileAppendRunsOnce(t *testing.T) {
+	for _, tt := range []struct {
+		operation string
+		attempts  int
+	}{
+		{models.FileOpAppend, 1},
+		{models.FileOpWrite, 3},
+	} {
+		dagStruct := models.DAGStructure{
+			Nodes: []models.Node{
+				{ID: "start", Type: "start"},
+				{ID: "log", Type: "file", Data: map[string]interface{}{"operation": tt.operation, "file": "log.txt", "path": "start"}},
+				{ID: "out", Type: "output"},
+			},
+			Edges: []models.Edge{
+				{ID: "e1", Source: "start", Target: "log"},
+				{ID: "e2", Source: "log", Target: "out"},
+			},
+		}
+		env, _ := newWorkflowTestEnv(t, dagStruct)
+		attempts := 0
+		env.RegisterActivityWithOptions(func(context.Context, FileInput) (*FileOutput, error) {
+			attempts++
+			return nil, errors.New("timed out")
+		}, activity.RegisterOptions{Name: "FileActivity"})
+
+		env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+		if env.GetWorkflowError() == nil {
+			t.Fatalf("%s: workflow succeeded, want the activity failure", tt.operation)
+		}
+		if attempts != tt.attempts {
+			t.Errorf("%s: ran %d attempts, want %d", tt.operation, attempts, tt.attempts)
+		}
+	}
+}
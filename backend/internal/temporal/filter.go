@@ -0,0 +1,310 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// FilterInput represents input for the filter node activity
+type FilterInput struct {
+	Filter models.FilterNodeData `json:"filter"`
+	Input  interface{}           `json:"input"`
+}
+
+// FilterOutput represents output from the filter node activity
+type FilterOutput struct {
+	Kept      []interface{} `json:"kept"`
+	Discarded []interface{} `json:"discarded"`
+}
+
+// FilterActivity keeps the items of an array that match the node's condition groups
+func (a *Activities) FilterActivity(ctx context.Context, input FilterInput) (*FilterOutput, error) {
+	items, err := itemsAt(input.Input, input.Filter.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compile regular expressions once for all items
+	patterns := make(map[string]*regexp.Regexp)
+	for _, group := range input.Filter.Groups {
+		for _, cond := range group.Conditions {
+			if cond.Operator != models.FilterOpRegex {
+				continue
+			}
+			pattern := stringify(cond.Value)
+			if cond.IgnoreCase {
+				pattern = "(?i)" + pattern
+			}
+			if _, ok := patterns[pattern]; ok {
+				continue
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex %q: %w", pattern, err)
+			}
+			patterns[pattern] = re
+		}
+	}
+
+	output := &FilterOutput{Kept: []interface{}{}, Discarded: []interface{}{}}
+	for _, item := range items {
+		match, err := matchGroups(item, input.Filter, patterns)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.Kept = append(output.Kept, item)
+		} else {
+			output.Discarded = append(output.Discarded, item)
+		}
+	}
+	return output, nil
+}
+
+// itemsAt returns the array found at path within the input
+func itemsAt(input interface{}, path string) ([]interface{}, error) {
+	value, ok := getPath(input, path)
+	if !ok {
+		return nil, fmt.Errorf("path %q not found in input", path)
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		if path == "" {
+			return nil, fmt.Errorf("input is %T, expected an array", value)
+		}
+		return nil, fmt.Errorf("%q is %T, expected an array", path, value)
+	}
+	return items, nil
+}
+
+func matchGroups(item interface{}, filter models.FilterNodeData, patterns map[string]*regexp.Regexp) (bool, error) {
+	if len(filter.Groups) == 0 {
+		return true, nil
+	}
+	matchAny := strings.EqualFold(filter.Combinator, "or")
+	for _, group := range filter.Groups {
+		match, err := matchGroup(item, group, patterns)
+		if err != nil {
+			return false, err
+		}
+		if matchAny && match {
+			return true, nil
+		}
+		if !matchAny && !match {
+			return false, nil
+		}
+	}
+	return !matchAny, nil
+}
+
+func matchGroup(item interface{}, group models.FilterGroup, patterns map[string]*regexp.Regexp) (bool, error) {
+	if len(group.Conditions) == 0 {
+		return true, nil
+	}
+	matchAny := strings.EqualFold(group.Combinator, "or")
+	for _, cond := range group.Conditions {
+		match, err := matchCondition(item, cond, patterns)
+		if err != nil {
+			return false, err
+		}
+		if matchAny && match {
+			return true, nil
+		}
+		if !matchAny && !match {
+			return false, nil
+		}
+	}
+	return !matchAny, nil
+}
+
+func matchCondition(item interface{}, cond models.FilterCondition, patterns map[string]*regexp.Regexp) (bool, error) {
+	actual, exists := getPath(item, cond.Field)
+	if !exists {
+		actual = nil
+	}
+
+	textOf := func(v interface{}) string {
+		s := stringify(v)
+		if cond.IgnoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+
+	switch cond.Operator {
+	case models.FilterOpEquals, models.FilterOpNotEquals:
+		equal := valuesEqual(actual, cond.Value, cond.IgnoreCase)
+		return equal == (cond.Operator == models.FilterOpEquals), nil
+	case models.FilterOpContains, models.FilterOpNotContains:
+		contains := false
+		if list, ok := actual.([]interface{}); ok {
+			for _, v := range list {
+				if valuesEqual(v, cond.Value, cond.IgnoreCase) {
+					contains = true
+					break
+				}
+			}
+		} else if actual != nil {
+			contains = strings.Contains(textOf(actual), textOf(cond.Value))
+		}
+		return contains == (cond.Operator == models.FilterOpContains), nil
+	case models.FilterOpStartsWith:
+		return actual != nil && strings.HasPrefix(textOf(actual), textOf(cond.Value)), nil
+	case models.FilterOpEndsWith:
+		return actual != nil && strings.HasSuffix(textOf(actual), textOf(cond.Value)), nil
+	case models.FilterOpRegex:
+		pattern := stringify(cond.Value)
+		if cond.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		return actual != nil && patterns[pattern].MatchString(stringify(actual)), nil
+	case models.FilterOpGt, models.FilterOpGte, models.FilterOpLt, models.FilterOpLte:
+		left, ok := toNumber(actual)
+		if !ok {
+			return false, nil
+		}
+		right, ok := toNumber(cond.Value)
+		if !ok {
+			return false, fmt.Errorf("operator %s needs a numeric value, got %v", cond.Operator, cond.Value)
+		}
+		switch cond.Operator {
+		case models.FilterOpGt:
+			return left > right, nil
+		case models.FilterOpGte:
+			return left >= right, nil
+		case models.FilterOpLt:
+			return left < right, nil
+		default:
+			return left <= right, nil
+		}
+	case models.FilterOpBefore, models.FilterOpAfter:
+		left, ok := parseTime(actual)
+		if !ok {
+			return false, nil
+		}
+		right, ok := parseTime(cond.Value)
+		if !ok {
+			return false, fmt.Errorf("operator %s needs a date value, got %v", cond.Operator, cond.Value)
+		}
+		if cond.Operator == models.FilterOpBefore {
+			return left.Before(right), nil
+		}
+		return left.After(right), nil
+	case models.FilterOpIsEmpty, models.FilterOpIsNotEmpty:
+		return isEmpty(actual) == (cond.Operator == models.FilterOpIsEmpty), nil
+	case models.FilterOpIsType:
+		return jsonTypeOf(actual) == stringify(cond.Value), nil
+	default:
+		return false, fmt.Errorf("unknown filter operator %q", cond.Operator)
+	}
+}
+
+func valuesEqual(a, b interface{}, ignoreCase bool) bool {
+	// Compare numbers numerically so "5" equals 5
+	if an, ok := a.(float64); ok {
+		if bn, ok := toNumber(b); ok {
+			return an == bn
+		}
+	}
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			if ignoreCase {
+				return strings.EqualFold(as, bs)
+			}
+			return as == bs
+		}
+		if _, ok := b.(float64); ok {
+			return as == stringify(b)
+		}
+	}
+	return reflect.DeepEqual(normalizeValue(a), normalizeValue(b))
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(val) == ""
+	case []interface{}:
+		return len(val) == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+func jsonTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// timeLayouts are tried in order when a value has no explicit layout
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parseTime accepts common date strings and Unix timestamps in seconds
+func parseTime(v interface{}) (time.Time, bool) {
+	switch val := v.(type) {
+	case string:
+		s := strings.TrimSpace(val)
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return time.Unix(0, int64(f*float64(time.Second))).UTC(), true
+		}
+	case float64:
+		return time.Unix(0, int64(val*float64(time.Second))).UTC(), true
+	case time.Time:
+		return val, true
+	}
+	return time.Time{}, false
+}
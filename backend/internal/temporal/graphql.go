@@ -0,0 +1,101 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// GraphQLInput represents input for the GraphQL request activity
+type GraphQLInput struct {
+	GraphQL models.GraphQLNodeData `json:"graphql"`
+	Input   interface{}            `json:"input"`
+}
+
+// GraphQLOutput represents the data and errors of a GraphQL response
+type GraphQLOutput struct {
+	Data   interface{}   `json:"data"`
+	Errors []interface{} `json:"errors,omitempty"`
+}
+
+// GraphQLActivity posts a query over the HTTP request transport. Responses
+// carrying errors fail unless AllowPartial is set and data came back too.
+func (a *Activities) GraphQLActivity(ctx context.Context, input GraphQLInput) (*GraphQLOutput, error) {
+	node := input.GraphQL
+	source := normalizeValue(input.Input)
+
+	body := map[string]interface{}{"query": node.Query}
+	if len(node.Variables) > 0 {
+		body["variables"] = resolveValue(node.Variables, source)
+	}
+	if node.OperationName != "" {
+		body["operationName"] = node.OperationName
+	}
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/graphql-response+json, application/json",
+	}
+	for name, value := range node.Headers {
+		headers[name] = stringify(resolveTemplate(value, source))
+	}
+
+	resp, err := a.HttpRequestActivity(ctx, HttpRequestInput{
+		Method:  http.MethodPost,
+		URL:     stringify(resolveTemplate(node.Endpoint, source)),
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, ok := resp.Data.(map[string]interface{})
+	_, hasData := envelope["data"]
+	_, hasErrors := envelope["errors"]
+	if !ok || (!hasData && !hasErrors) {
+		err := fmt.Errorf("graphql endpoint returned status %d without a graphql response", resp.StatusCode)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, err
+		}
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "GraphQLError", nil)
+	}
+
+	output := &GraphQLOutput{Data: envelope["data"]}
+	output.Errors, _ = envelope["errors"].([]interface{})
+	if len(output.Errors) > 0 && (!node.AllowPartial || output.Data == nil) {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"graphql errors: "+graphQLErrorMessages(output.Errors), "GraphQLError", nil)
+	}
+	if output.Data == nil && resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("graphql endpoint returned status %d", resp.StatusCode)
+	}
+	return output, nil
+}
+
+// graphQLErrorMessages joins the message of each error, with its path when
+// one is given
+func graphQLErrorMessages(errs []interface{}) string {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		obj, ok := e.(map[string]interface{})
+		if !ok {
+			messages = append(messages, stringify(e))
+			continue
+		}
+		msg := stringify(obj["message"])
+		if path, ok := obj["path"].([]interface{}); ok && len(path) > 0 {
+			parts := make([]string, len(path))
+			for i, p := range path {
+				parts[i] = stringify(p)
+			}
+			msg = strings.Join(parts, ".") + ": " + msg
+		}
+		messages = append(messages, msg)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,301 @@
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/pkg/protoset"
+)
+
+// GrpcInput represents input for the gRPC request activity
+type GrpcInput struct {
+	Grpc  models.GrpcNodeData `json:"grpc"`
+	Input interface{}         `json:"input"`
+}
+
+// GrpcOutput represents output from the gRPC request activity
+type GrpcOutput struct {
+	Response interface{}         `json:"response"`
+	Headers  map[string][]string `json:"headers,omitempty"`
+}
+
+// Reflection is tried on v1 first, then on v1alpha for older servers. Both
+// services use the same messages.
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// GrpcActivity calls a unary method, encoding the request and decoding the
+// response with descriptors from server reflection or an uploaded set
+func (a *Activities) GrpcActivity(ctx context.Context, input GrpcInput) (*GrpcOutput, error) {
+	node := input.Grpc
+	source := normalizeValue(input.Input)
+
+	service, method, ok := protoset.SplitMethod(node.Method)
+	if !ok {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid method name %q", node.Method), "InvalidData", nil)
+	}
+
+	transport := insecure.NewCredentials()
+	if node.TLS || node.Credential != "" {
+		cfg := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         node.ServerName,
+			InsecureSkipVerify: node.InsecureSkipVerify,
+		}
+		if node.Credential != "" {
+			cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeTLS)
+			if err != nil {
+				return nil, err
+			}
+			if err := applyTLSCredential(cfg, cred); err != nil {
+				return nil, err
+			}
+		}
+		transport = credentials.NewTLS(cfg)
+	}
+	conn, err := grpc.NewClient(stringify(resolveTemplate(node.Address, source)), grpc.WithTransportCredentials(transport))
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid address: %v", err), "InvalidData", err)
+	}
+	defer conn.Close()
+
+	if node.Timeout != "" {
+		timeout, err := time.ParseDuration(node.Timeout)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid timeout %q", node.Timeout), "InvalidData", err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	md := metadata.MD{}
+	for key, value := range node.Metadata {
+		md.Append(key, stringify(resolveTemplate(value, source)))
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	var files *protoregistry.Files
+	if node.Descriptor != "" {
+		files, err = a.loadProtoDescriptor(ctx, node.Descriptor)
+	} else {
+		files, err = reflectFiles(ctx, conn, service)
+	}
+	if err != nil {
+		return nil, err
+	}
+	desc, err := protoset.FindMethod(files, node.Method)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "InvalidData", err)
+	}
+	if desc.IsStreamingClient() || desc.IsStreamingServer() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%s is a streaming method; only unary calls are supported", node.Method), "InvalidData", nil)
+	}
+
+	types := dynamicpb.NewTypes(files)
+	req := dynamicpb.NewMessage(desc.Input())
+	if node.Request != nil {
+		reqJSON, err := json.Marshal(resolveValue(node.Request, source))
+		if err != nil {
+			return nil, err
+		}
+		if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(reqJSON, req); err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("request does not match %s: %v", desc.Input().FullName(), err), "InvalidData", err)
+		}
+	}
+
+	resp := dynamicpb.NewMessage(desc.Output())
+	var header metadata.MD
+	if err := conn.Invoke(ctx, "/"+service+"/"+method, req, resp, grpc.Header(&header)); err != nil {
+		return nil, classifyGrpcError(err)
+	}
+
+	respJSON, err := protojson.MarshalOptions{Resolver: types, EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	output := &GrpcOutput{Headers: header}
+	if err := json.Unmarshal(respJSON, &output.Response); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// loadProtoDescriptor links an uploaded descriptor set, by ID when ref is a
+// UUID and by name otherwise
+func (a *Activities) loadProtoDescriptor(ctx context.Context, ref string) (*protoregistry.Files, error) {
+	query := `SELECT data FROM proto_descriptors WHERE name = $1`
+	if _, err := uuid.Parse(ref); err == nil {
+		query = `SELECT data FROM proto_descriptors WHERE id = $1`
+	}
+	var data []byte
+	err := a.DB.QueryRowContext(ctx, query, ref).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("descriptor %q not found", ref), "DescriptorNotFound", err)
+		}
+		return nil, err
+	}
+	files, err := protoset.Parse(data)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("descriptor %q: %v", ref, err), "InvalidData", err)
+	}
+	return files, nil
+}
+
+// reflectFiles asks the server for the file defining service and everything
+// it imports
+func reflectFiles(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	var err error
+	for _, fullMethod := range reflectionMethods {
+		var fds []*descriptorpb.FileDescriptorProto
+		fds, err = reflectDescriptors(ctx, conn, fullMethod, service)
+		if status.Code(err) == codes.Unimplemented {
+			continue
+		}
+		if err != nil {
+			return nil, classifyGrpcError(err)
+		}
+		files, err := protoset.Build(fds)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("server reflection returned unusable descriptors: %v", err), "InvalidData", err)
+		}
+		return files, nil
+	}
+	return nil, temporal.NewNonRetryableApplicationError(
+		"server does not support reflection; upload a descriptor set instead", "ReflectionUnavailable", err)
+}
+
+func reflectDescriptors(ctx context.Context, conn *grpc.ClientConn, fullMethod, service string) ([]*descriptorpb.FileDescriptorProto, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var fds []*descriptorpb.FileDescriptorProto
+	pending := []*reflectionpb.ServerReflectionRequest{{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}}
+	for len(pending) > 0 {
+		req := pending[0]
+		pending = pending[1:]
+		if err := stream.SendMsg(req); err != nil {
+			return nil, err
+		}
+		var resp reflectionpb.ServerReflectionResponse
+		if err := stream.RecvMsg(&resp); err != nil {
+			return nil, err
+		}
+		if e := resp.GetErrorResponse(); e != nil {
+			return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+		}
+
+		// Servers usually send the imports along; fetch any that are missing
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var fd descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(raw, &fd); err != nil {
+				return nil, err
+			}
+			if seen[fd.GetName()] {
+				continue
+			}
+			seen[fd.GetName()] = true
+			fds = append(fds, &fd)
+		}
+		for _, fd := range fds {
+			for _, dep := range fd.GetDependency() {
+				if seen[dep] {
+					continue
+				}
+				seen[dep] = true
+				if _, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+					continue // well-known types are linked in
+				}
+				pending = append(pending, &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				})
+			}
+		}
+	}
+	return fds, stream.CloseSend()
+}
+
+// classifyGrpcError keeps transient statuses retryable and marks the rest
+// (bad requests, missing methods, permission errors) as non-retryable.
+// INTERNAL and UNKNOWN are not retried: the server may have acted on the call
+// before failing.
+func classifyGrpcError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return err
+	}
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("%s: %s", st.Code(), st.Message()), "GrpcError", err)
+}
+
+// applyTLSCredential adds a tls credential to cfg: caCert (PEM bundle) replaces
+// the system roots, and clientCert with clientKey (PEM) is presented to
+// servers that ask for a client certificate
+func applyTLSCredential(cfg *tls.Config, cred *models.Credential) error {
+	if ca := credentialString(cred, "caCert"); ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has no valid certificate in caCert", cred.Name), "InvalidCredential", nil)
+		}
+		cfg.RootCAs = pool
+	}
+
+	certPEM, keyPEM := credentialString(cred, "clientCert"), credentialString(cred, "clientKey")
+	if (certPEM == "") != (keyPEM == "") {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q needs both clientCert and clientKey", cred.Name), "InvalidCredential", nil)
+	}
+	if certPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has an invalid client certificate: %v", cred.Name, err), "InvalidCredential", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
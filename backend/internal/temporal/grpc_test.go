@@ -0,0 +1,163 @@
+package temporal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// testCert issues a certificate for name signed by parent, or a self-signed
+// CA when parent is nil. It returns the certificate and key as PEM.
+func testCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{name},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, string(certPEM), string(keyPEM)
+}
+
+func TestApplyTLSCredentialMutualTLS(t *testing.T) {
+	ca, caKey, caPEM, _ := testCert(t, "test-ca", nil, nil)
+	_, _, serverCertPEM, serverKeyPEM := testCert(t, "grpc.internal", ca, caKey)
+	_, _, clientCertPEM, clientKeyPEM := testCert(t, "worker", ca, caKey)
+
+	serverCert, err := tls.X509KeyPair([]byte(serverCertPEM), []byte(serverKeyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	serverCfg := &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: "grpc.internal"}
+	cred := &models.Credential{Name: "mtls", Data: map[string]interface{}{
+		"caCert":     caPEM,
+		"clientCert": clientCertPEM,
+		"clientKey":  clientKeyPEM,
+	}}
+	if err := applyTLSCredential(cfg, cred); err != nil {
+		t.Fatal(err)
+	}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- tls.Server(serverConn, serverCfg).Handshake()
+	}()
+	if err := tls.Client(clientConn, cfg).Handshake(); err != nil {
+		t.Fatalf("client handshake: %v", err)
+	}
+	if err := <-serverErr; err != nil {
+		t.Fatalf("server handshake: %v", err)
+	}
+}
+
+func TestApplyTLSCredentialInvalid(t *testing.T) {
+	ca, caKey, caPEM, _ := testCert(t, "test-ca", nil, nil)
+	_, _, certPEM, keyPEM := testCert(t, "worker", ca, caKey)
+	_, _, _, otherKeyPEM := testCert(t, "other", ca, caKey)
+
+	tests := map[string]map[string]interface{}{
+		"caCert is not PEM":  {"caCert": "not a certificate"},
+		"clientKey missing":  {"caCert": caPEM, "clientCert": certPEM},
+		"clientCert missing": {"clientKey": keyPEM},
+		"mismatched key":     {"clientCert": certPEM, "clientKey": otherKeyPEM},
+	}
+	for name, data := range tests {
+		err := applyTLSCredential(&tls.Config{}, &models.Credential{Name: "bad", Data: data})
+		if !isNonRetryable(err) {
+			t.Errorf("%s: got %v, want a non-retryable error", name, err)
+		}
+	}
+}
+
+func TestClassifyGrpcError(t *testing.T) {
+	retryable := []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted}
+	for _, code := range retryable {
+		if err := classifyGrpcError(status.Error(code, "try later")); isNonRetryable(err) {
+			t.Errorf("%s: got a non-retryable error, want a retry", code)
+		}
+	}
+	// The server may have acted on the call before answering these
+	final := []codes.Code{codes.Internal, codes.Unknown, codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.Unimplemented}
+	for _, code := range final {
+		if err := classifyGrpcError(status.Error(code, "no")); !isNonRetryable(err) {
+			t.Errorf("%s: got %v, want a non-retryable error", code, err)
+		}
+	}
+}
+
+func TestGrpcActivityTimeoutCoversCallDeadline(t *testing.T) {
+	dagStruct := models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "call", Type: "grpc", Data: map[string]interface{}{
+				"address": "inventory.internal:9090",
+				"method":  "inventory.v1.StockService/GetStock",
+				"timeout": "2m",
+			}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "call"},
+			{ID: "e2", Source: "call", Target: "out"},
+		},
+	}
+	env, _ := newWorkflowTestEnv(t, dagStruct)
+	var startToClose time.Duration
+	env.RegisterActivityWithOptions(func(ctx context.Context, _ GrpcInput) (*GrpcOutput, error) {
+		startToClose = activity.GetInfo(ctx).StartToCloseTimeout
+		return &GrpcOutput{}, nil
+	}, activity.RegisterOptions{Name: "GrpcActivity"})
+
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+	if startToClose <= 2*time.Minute {
+		t.Errorf("activity StartToCloseTimeout = %s, want more than the 2m call deadline", startToClose)
+	}
+}
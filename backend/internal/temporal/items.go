@@ -0,0 +1,179 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// ItemsInput represents input for the items node activity
+type ItemsInput struct {
+	Path       string                  `json:"path"`
+	Operations []models.ItemsOperation `json:"operations"`
+	Input      interface{}             `json:"input"`
+}
+
+// ItemsActivity applies list operations to the input array in Go, avoiding
+// the goja round trip for large arrays
+func (a *Activities) ItemsActivity(ctx context.Context, input ItemsInput) ([]interface{}, error) {
+	items, err := itemsAt(input.Input, input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range input.Operations {
+		switch op.Type {
+		case models.ItemsOpSort:
+			items = sortItems(items, op.Sort)
+		case models.ItemsOpLimit:
+			items = limitItems(items, op.Offset, op.Limit)
+		case models.ItemsOpDedupe:
+			items, err = dedupeItems(items, op.Keys)
+			if err != nil {
+				return nil, err
+			}
+		case models.ItemsOpReverse:
+			reversed := make([]interface{}, len(items))
+			for i, item := range items {
+				reversed[len(items)-1-i] = item
+			}
+			items = reversed
+		case models.ItemsOpFlatten:
+			depth := op.Depth
+			if depth == 0 {
+				depth = 1
+			}
+			items = flattenItems(items, depth)
+		default:
+			return nil, fmt.Errorf("unknown items operation %q", op.Type)
+		}
+	}
+
+	return items, nil
+}
+
+func sortItems(items []interface{}, fields []models.SortField) []interface{} {
+	// Extract sort keys once rather than on every comparison
+	keys := make([][]interface{}, len(items))
+	for i, item := range items {
+		keys[i] = make([]interface{}, len(fields))
+		for j, f := range fields {
+			keys[i][j], _ = getPath(item, f.Field)
+		}
+	}
+
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		for j, f := range fields {
+			ka, kb := keys[idx[a]][j], keys[idx[b]][j]
+			// Missing values sort last in both directions
+			if ka == nil || kb == nil {
+				if (ka == nil) == (kb == nil) {
+					continue
+				}
+				return kb == nil
+			}
+			c := compareValues(ka, kb)
+			if c == 0 {
+				continue
+			}
+			if strings.EqualFold(f.Direction, "desc") {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]interface{}, len(items))
+	for i, j := range idx {
+		sorted[i] = items[j]
+	}
+	return sorted
+}
+
+// compareValues orders numbers numerically, strings lexically and false
+// before true
+func compareValues(a, b interface{}) int {
+	if an, ok := toNumber(a); ok {
+		if bn, ok := toNumber(b); ok {
+			switch {
+			case an < bn:
+				return -1
+			case an > bn:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	if ab, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			switch {
+			case ab == bb:
+				return 0
+			case !ab:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+	return strings.Compare(stringify(a), stringify(b))
+}
+
+func limitItems(items []interface{}, offset, limit int) []interface{} {
+	if offset >= len(items) {
+		return []interface{}{}
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// dedupeItems keeps the first item for every distinct key
+func dedupeItems(items []interface{}, keys []string) ([]interface{}, error) {
+	seen := make(map[string]struct{}, len(items))
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		var identity interface{} = item
+		if len(keys) > 0 {
+			values := make([]interface{}, len(keys))
+			for i, k := range keys {
+				values[i], _ = getPath(item, k)
+			}
+			identity = values
+		}
+		b, err := json.Marshal(identity)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[string(b)]; ok {
+			continue
+		}
+		seen[string(b)] = struct{}{}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func flattenItems(items []interface{}, depth int) []interface{} {
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if nested, ok := item.([]interface{}); ok && depth > 0 {
+			out = append(out, flattenItems(nested, depth-1)...)
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
@@ -0,0 +1,298 @@
+package temporal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// JWTInput represents input for the JWT activity
+type JWTInput struct {
+	JWT   models.JWTNodeData `json:"jwt"`
+	Input interface{}        `json:"input"`
+}
+
+// JWTOutput represents output from the JWT activity: the signed token, or
+// the verified token's claims and header
+type JWTOutput struct {
+	Token  string                 `json:"token,omitempty"`
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	Header map[string]interface{} `json:"header,omitempty"`
+}
+
+// JWTActivity signs a token with a key from the credential store or
+// verifies one against a credential or JWKS document
+func (a *Activities) JWTActivity(ctx context.Context, input JWTInput) (*JWTOutput, error) {
+	node := input.JWT
+	source := normalizeValue(input.Input)
+
+	switch node.Operation {
+	case "sign":
+		return a.signJWT(ctx, node, source)
+	case "verify":
+		return a.verifyJWT(ctx, node, source)
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown jwt operation %q", node.Operation), "InvalidData", nil)
+	}
+}
+
+func (a *Activities) signJWT(ctx context.Context, node models.JWTNodeData, source interface{}) (*JWTOutput, error) {
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeJWT)
+	if err != nil {
+		return nil, err
+	}
+
+	var method jwt.SigningMethod
+	var key interface{}
+	field := "privateKey"
+	switch node.Algorithm {
+	case "HS256":
+		method = jwt.SigningMethodHS256
+		field = "secret"
+		key, err = credentialSecret(cred)
+	case "RS256":
+		method = jwt.SigningMethodRS256
+		key, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(credentialString(cred, "privateKey")))
+	case "ES256":
+		method = jwt.SigningMethodES256
+		key, err = jwt.ParseECPrivateKeyFromPEM([]byte(credentialString(cred, "privateKey")))
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unsupported jwt algorithm %q", node.Algorithm), "InvalidData", nil)
+	}
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has an invalid %s: %v", cred.Name, field, err), "InvalidCredential", err)
+	}
+
+	claims := jwt.MapClaims{}
+	for name, value := range node.Claims {
+		claims[name] = resolveValue(value, source)
+	}
+	for name, value := range map[string]string{"sub": node.Subject, "iss": node.Issuer, "aud": node.Audience} {
+		if value != "" {
+			claims[name] = stringify(resolveTemplate(value, source))
+		}
+	}
+	now := time.Now()
+	claims["iat"] = now.Unix()
+	if node.ExpiresIn != "" {
+		ttl, err := time.ParseDuration(node.ExpiresIn)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid expiresIn %q", node.ExpiresIn), "InvalidData", err)
+		}
+		claims["exp"] = now.Add(ttl).Unix()
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	kid := node.KeyID
+	if kid == "" {
+		kid = credentialString(cred, "keyId")
+	}
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("failed to sign token: %v", err), "InvalidCredential", err)
+	}
+	return &JWTOutput{Token: signed}, nil
+}
+
+// verifyJWT checks the signature, expiry (which must be present), issuer
+// and audience of a token
+func (a *Activities) verifyJWT(ctx context.Context, node models.JWTNodeData, source interface{}) (*JWTOutput, error) {
+	raw := strings.TrimSpace(stringify(resolveTemplate(node.Token, source)))
+	if len(raw) > 7 && strings.EqualFold(raw[:7], "bearer ") {
+		raw = strings.TrimSpace(raw[7:])
+	}
+
+	var keyFunc jwt.Keyfunc
+	if node.JWKSURL != "" {
+		keys, err := fetchJWKS(ctx, node.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		keyFunc = keys.keyFor
+	} else {
+		cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeJWT)
+		if err != nil {
+			return nil, err
+		}
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			switch token.Method.(type) {
+			case *jwt.SigningMethodHMAC:
+				return credentialSecret(cred)
+			case *jwt.SigningMethodRSA:
+				return jwt.ParseRSAPublicKeyFromPEM([]byte(credentialString(cred, "publicKey")))
+			case *jwt.SigningMethodECDSA:
+				return jwt.ParseECPublicKeyFromPEM([]byte(credentialString(cred, "publicKey")))
+			}
+			return nil, fmt.Errorf("unsupported signing method %s", token.Method.Alg())
+		}
+	}
+
+	methods := []string{"HS256", "RS256", "ES256"}
+	if node.Algorithm != "" {
+		methods = []string{node.Algorithm}
+	}
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired(), jwt.WithIssuedAt()}
+	if node.Leeway != "" {
+		leeway, err := time.ParseDuration(node.Leeway)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid leeway %q", node.Leeway), "InvalidData", err)
+		}
+		opts = append(opts, jwt.WithLeeway(leeway))
+	}
+	if node.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(stringify(resolveTemplate(node.Issuer, source))))
+	}
+	if node.Audience != "" {
+		opts = append(opts, jwt.WithAudience(stringify(resolveTemplate(node.Audience, source))))
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(raw, claims, keyFunc, opts...)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid token: %v", err), "InvalidToken", err)
+	}
+	return &JWTOutput{Claims: claims, Header: token.Header}, nil
+}
+
+// credentialSecret returns the HMAC secret of a jwt credential
+func credentialSecret(cred *models.Credential) ([]byte, error) {
+	secret := credentialString(cred, "secret")
+	if secret == "" {
+		return nil, fmt.Errorf("credential %q has no secret", cred.Name)
+	}
+	return []byte(secret), nil
+}
+
+// jwks is a parsed JSON Web Key Set holding RSA and P-256 public keys
+type jwks []jwk
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func fetchJWKS(ctx context.Context, jwksURL string) (jwks, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid jwks url: %v", err), "InvalidData", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("jwks url returned status %d", resp.StatusCode)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, err
+		}
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "JWKSError", nil)
+	}
+
+	var doc struct {
+		Keys jwks `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid jwks document: %v", err), "JWKSError", err)
+	}
+	return doc.Keys, nil
+}
+
+// keyFor picks the signing key named by the token's kid, or the only
+// signing key of the right type when the token has none
+func (keys jwks) keyFor(token *jwt.Token) (interface{}, error) {
+	kty := "RSA"
+	if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+		kty = "EC"
+	}
+	kid, _ := token.Header["kid"].(string)
+
+	var match *jwk
+	for i := range keys {
+		k := &keys[i]
+		if k.Kty != kty || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		if kid != "" && k.Kid == kid {
+			match = k
+			break
+		}
+		if kid == "" {
+			if match != nil {
+				return nil, errors.New("token has no kid and the jwks holds several keys")
+			}
+			match = k
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no %s key with kid %q in jwks", kty, kid)
+	}
+	return match.publicKey()
+}
+
+func (k *jwk) publicKey() (interface{}, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwk %q: invalid modulus: %w", k.Kid, err)
+		}
+		e, err := decode(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("jwk %q: invalid exponent", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("jwk %q: unsupported curve %q", k.Kid, k.Crv)
+		}
+		x, errX := decode(k.X)
+		y, errY := decode(k.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("jwk %q: invalid point", k.Kid)
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+	}
+	return nil, fmt.Errorf("jwk %q: unsupported key type %q", k.Kid, k.Kty)
+}
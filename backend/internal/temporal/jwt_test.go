@@ -0,0 +1,246 @@
+package temporal
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func expectJWTCredential(mock sqlmock.Sqlmock, data map[string]string) {
+	raw, _ := json.Marshal(data)
+	now := time.Now()
+	mock.ExpectQuery("FROM credentials").WithArgs("signing").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "type", "data", "created_at", "updated_at"}).
+			AddRow("cred-1", "signing", models.CredentialTypeJWT, raw, now, now))
+}
+
+func newJWTTest(t *testing.T) (*Activities, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Activities{DB: db}, mock
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{"sub": "ada", "iss": "auth.example.com", "aud": "orders", "iat": now.Unix(), "exp": now.Add(time.Minute).Unix()}
+}
+
+func TestVerifyJWTWithSecret(t *testing.T) {
+	a, mock := newJWTTest(t)
+	secret := map[string]string{"secret": "s3cret"}
+	node := models.JWTNodeData{Operation: "verify", Credential: "signing", Token: "{{ headers.authorization }}", Issuer: "auth.example.com", Audience: "orders"}
+	verify := func(token string, node models.JWTNodeData) (*JWTOutput, error) {
+		expectJWTCredential(mock, secret)
+		input := map[string]interface{}{"headers": map[string]interface{}{"authorization": "Bearer " + token}}
+		return a.JWTActivity(context.Background(), JWTInput{JWT: node, Input: input})
+	}
+
+	out, err := verify(signTestToken(t, jwt.SigningMethodHS256, []byte("s3cret"), "", validClaims()), node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Claims["sub"] != "ada" || out.Header["alg"] != "HS256" {
+		t.Errorf("output = %+v", out)
+	}
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	noExp := validClaims()
+	delete(noExp, "exp")
+	wrongAud := validClaims()
+	wrongAud["aud"] = "billing"
+	wrongIss := validClaims()
+	wrongIss["iss"] = "evil.example.com"
+	tests := map[string]string{
+		"expired":        signTestToken(t, jwt.SigningMethodHS256, []byte("s3cret"), "", expired),
+		"without exp":    signTestToken(t, jwt.SigningMethodHS256, []byte("s3cret"), "", noExp),
+		"wrong audience": signTestToken(t, jwt.SigningMethodHS256, []byte("s3cret"), "", wrongAud),
+		"wrong issuer":   signTestToken(t, jwt.SigningMethodHS256, []byte("s3cret"), "", wrongIss),
+		"wrong secret":   signTestToken(t, jwt.SigningMethodHS256, []byte("guess"), "", validClaims()),
+		"alg none":       signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()),
+		"HS512":          signTestToken(t, jwt.SigningMethodHS512, []byte("s3cret"), "", validClaims()),
+	}
+	for name, token := range tests {
+		if _, err := verify(token, node); !isNonRetryable(err) {
+			t.Errorf("%s: got %v, want a non-retryable invalid token error", name, err)
+		}
+	}
+
+	// Leeway accepts a token that expired within it
+	node.Leeway = "5m"
+	if _, err := verify(tests["expired"], node); err != nil {
+		t.Errorf("expired within leeway: %v", err)
+	}
+}
+
+func TestVerifyJWTAlgorithm(t *testing.T) {
+	a, mock := newJWTTest(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	cred := map[string]string{"publicKey": string(publicPEM)}
+	verify := func(token, algorithm string) error {
+		expectJWTCredential(mock, cred)
+		_, err := a.JWTActivity(context.Background(), JWTInput{JWT: models.JWTNodeData{
+			Operation: "verify", Credential: "signing", Algorithm: algorithm, Token: token,
+		}})
+		return err
+	}
+
+	rs256 := signTestToken(t, jwt.SigningMethodRS256, key, "", validClaims())
+	if err := verify(rs256, "RS256"); err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(rs256, "ES256"); !isNonRetryable(err) {
+		t.Errorf("pinned ES256: got %v, want the RS256 token rejected", err)
+	}
+	// The classic confusion: an HS256 token keyed with the public key PEM
+	forged := signTestToken(t, jwt.SigningMethodHS256, publicPEM, "", validClaims())
+	for _, algorithm := range []string{"", "RS256"} {
+		if err := verify(forged, algorithm); !isNonRetryable(err) {
+			t.Errorf("algorithm %q: got %v, want the forged HS256 token rejected", algorithm, err)
+		}
+	}
+}
+
+// jwkFor encodes a public key as a JWK
+func jwkFor(t *testing.T, kid string, pub interface{}) map[string]string {
+	t.Helper()
+	enc := base64.RawURLEncoding.EncodeToString
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kid": kid, "kty": "RSA", "use": "sig", "n": enc(pub.N.Bytes()), "e": enc(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		point, err := pub.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return map[string]string{"kid": kid, "kty": "EC", "crv": "P-256", "x": enc(point[1:33]), "y": enc(point[33:])}
+	}
+	t.Fatalf("unsupported key %T", pub)
+	return nil
+}
+
+func serveJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestVerifyJWTWithJWKS(t *testing.T) {
+	rsaOld, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaNew, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	url := serveJWKS(t,
+		jwkFor(t, "old", &rsaOld.PublicKey),
+		jwkFor(t, "new", &rsaNew.PublicKey),
+		jwkFor(t, "ec", &ecKey.PublicKey),
+	)
+	verify := func(token string) (*JWTOutput, error) {
+		return (&Activities{}).JWTActivity(context.Background(), JWTInput{JWT: models.JWTNodeData{
+			Operation: "verify", JWKSURL: url, Token: token,
+		}})
+	}
+
+	// The kid picks the key among several of the same type
+	for _, tt := range []struct {
+		kid string
+		key *rsa.PrivateKey
+	}{{"old", rsaOld}, {"new", rsaNew}} {
+		out, err := verify(signTestToken(t, jwt.SigningMethodRS256, tt.key, tt.kid, validClaims()))
+		if err != nil {
+			t.Fatalf("kid %s: %v", tt.kid, err)
+		}
+		if out.Header["kid"] != tt.kid {
+			t.Errorf("header = %v, want kid %s", out.Header, tt.kid)
+		}
+	}
+
+	// The only EC key is used when the token names none
+	if _, err := verify(signTestToken(t, jwt.SigningMethodES256, ecKey, "", validClaims())); err != nil {
+		t.Errorf("EC without kid: %v", err)
+	}
+
+	tests := map[string]string{
+		"kid of another key": signTestToken(t, jwt.SigningMethodRS256, rsaOld, "new", validClaims()),
+		"unknown kid":        signTestToken(t, jwt.SigningMethodRS256, rsaNew, "rotated", validClaims()),
+		"no kid, two keys":   signTestToken(t, jwt.SigningMethodRS256, rsaNew, "", validClaims()),
+		"EC kid for RSA":     signTestToken(t, jwt.SigningMethodRS256, rsaNew, "ec", validClaims()),
+	}
+	for name, token := range tests {
+		if _, err := verify(token); !isNonRetryable(err) {
+			t.Errorf("%s: got %v, want a non-retryable invalid token error", name, err)
+		}
+	}
+}
+
+func TestSignJWTRoundTrip(t *testing.T) {
+	a, mock := newJWTTest(t)
+	cred := map[string]string{"secret": "s3cret", "keyId": "v1"}
+	expectJWTCredential(mock, cred)
+	signed, err := a.JWTActivity(context.Background(), JWTInput{
+		JWT: models.JWTNodeData{
+			Operation: "sign", Algorithm: "HS256", Credential: "signing",
+			Subject: "{{ user.id }}", Audience: "orders", ExpiresIn: "5m",
+			Claims: map[string]interface{}{"role": "{{ user.role }}"},
+		},
+		Input: map[string]interface{}{"user": map[string]interface{}{"id": "u-1", "role": "admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectJWTCredential(mock, cred)
+	out, err := a.JWTActivity(context.Background(), JWTInput{JWT: models.JWTNodeData{
+		Operation: "verify", Credential: "signing", Audience: "orders", Token: signed.Token,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Claims["sub"] != "u-1" || out.Claims["role"] != "admin" || out.Header["kid"] != "v1" {
+		t.Errorf("claims = %v, header = %v", out.Claims, out.Header)
+	}
+	if exp, _ := out.Claims["exp"].(float64); time.Until(time.Unix(int64(exp), 0)) > 5*time.Minute {
+		t.Errorf("exp = %v, want at most five minutes out", out.Claims["exp"])
+	}
+}
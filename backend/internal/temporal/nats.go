@@ -0,0 +1,167 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+const defaultNATSRequestTimeout = 5 * time.Second
+
+// NATSInput represents input for the NATS activity
+type NATSInput struct {
+	NATS  models.NATSNodeData `json:"nats"`
+	Input interface{}         `json:"input"`
+}
+
+// NATSOutput represents output from the NATS activity; Data and Headers hold
+// the reply to a request
+type NATSOutput struct {
+	Subject string              `json:"subject"`
+	Data    interface{}         `json:"data,omitempty"`
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// natsConn adapts a NATS connection to io.Closer for the shared client cache
+type natsConn struct {
+	*nats.Conn
+}
+
+func (c natsConn) Close() error {
+	c.Conn.Close()
+	return nil
+}
+
+// NATSActivity publishes a message, or sends a request and waits for the
+// reply, on the server of a nats credential
+func (a *Activities) NATSActivity(ctx context.Context, input NATSInput) (*NATSOutput, error) {
+	node := input.NATS
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeNATS)
+	if err != nil {
+		return nil, err
+	}
+	client, release, err := a.clients.get(cred, func() (io.Closer, error) {
+		return openNATS(cred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return runNATS(ctx, client.(natsConn).Conn, node, normalizeValue(input.Input))
+}
+
+// runNATS publishes or sends the node's request with its fields resolved
+// against source
+func runNATS(ctx context.Context, nc *nats.Conn, node models.NATSNodeData, source interface{}) (*NATSOutput, error) {
+	var err error
+	msg := nats.NewMsg(stringify(resolveTemplate(node.Subject, source)))
+	if msg.Data, err = natsPayload(node.Payload, source); err != nil {
+		return nil, err
+	}
+	for key, value := range node.Headers {
+		msg.Header.Set(key, stringify(resolveTemplate(value, source)))
+	}
+	output := &NATSOutput{Subject: msg.Subject}
+
+	switch node.Operation {
+	case models.NATSOpPublish:
+		if err := nc.PublishMsg(msg); err != nil {
+			return nil, classifyNATSError(err)
+		}
+		// Flushing makes the server acknowledge the message before the node succeeds
+		if err := nc.FlushWithContext(ctx); err != nil {
+			return nil, classifyNATSError(err)
+		}
+	case models.NATSOpRequest:
+		timeout := defaultNATSRequestTimeout
+		if node.Timeout != "" {
+			if timeout, err = time.ParseDuration(node.Timeout); err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("invalid timeout %q", node.Timeout), "InvalidData", err)
+			}
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		reply, err := nc.RequestMsgWithContext(reqCtx, msg)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, fmt.Errorf("no reply on %q within %s", msg.Subject, timeout)
+		}
+		if err != nil {
+			return nil, classifyNATSError(err)
+		}
+		output.Data = decodeNATSData(reply.Data)
+		if len(reply.Header) > 0 {
+			output.Headers = reply.Header
+		}
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown nats operation %q", node.Operation), "InvalidData", nil)
+	}
+	return output, nil
+}
+
+// openNATS connects with a nats credential holding url (servers may be comma
+// separated) and optionally username and password or token
+func openNATS(cred *models.Credential) (natsConn, error) {
+	url := credentialString(cred, "url")
+	if url == "" {
+		return natsConn{}, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has no url", cred.Name), "InvalidCredential", nil)
+	}
+	opts := []nats.Option{nats.Name("n8n-clone worker"), nats.MaxReconnects(-1)}
+	if user := credentialString(cred, "username"); user != "" {
+		opts = append(opts, nats.UserInfo(user, credentialString(cred, "password")))
+	}
+	if token := credentialString(cred, "token"); token != "" {
+		opts = append(opts, nats.Token(token))
+	}
+	nc, err := nats.Connect(url, opts...)
+	if err != nil {
+		if errors.Is(err, nats.ErrAuthorization) {
+			return natsConn{}, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q: %v", cred.Name, err), "InvalidCredential", err)
+		}
+		return natsConn{}, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	return natsConn{nc}, nil
+}
+
+// natsPayload encodes the message body: strings are sent as is, other values
+// as JSON. Without a payload the node's input is sent.
+func natsPayload(payload, source interface{}) ([]byte, error) {
+	value := source
+	if payload != nil {
+		value = resolveValue(payload, source)
+	}
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
+
+// decodeNATSData parses a JSON message body, falling back to the raw text
+func decodeNATSData(data []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err == nil {
+		return v
+	}
+	return string(data)
+}
+
+// classifyNATSError marks malformed subjects and oversized messages as
+// non-retryable; missing responders and connection problems are retried
+func classifyNATSError(err error) error {
+	switch {
+	case errors.Is(err, nats.ErrBadSubject), errors.Is(err, nats.ErrMaxPayload), errors.Is(err, nats.ErrHeadersNotSupported):
+		return temporal.NewNonRetryableApplicationError(err.Error(), "NATSError", err)
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"go.temporal.io/sdk/activity"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// newTestNATS starts an in-process NATS server on a random port and connects
+// to it
+func newTestNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	srv := natsserver.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+// activityContext carries a deadline like the context Temporal gives activities
+func activityContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestRunNATSPublish(t *testing.T) {
+	nc := newTestNATS(t)
+	sub, err := nc.SubscribeSync("orders.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := map[string]interface{}{"id": "7", "total": 12.5}
+
+	node := models.NATSNodeData{
+		Operation: models.NATSOpPublish,
+		Subject:   "orders.{{ id }}",
+		Headers:   map[string]string{"Order-Id": "{{ id }}"},
+	}
+	out, err := runNATS(activityContext(t), nc, node, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Subject != "orders.7" {
+		t.Errorf("subject = %q, want orders.7", out.Subject)
+	}
+
+	msg, err := sub.NextMsg(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decodeNATSData(msg.Data); !reflect.DeepEqual(got, source) {
+		t.Errorf("data = %#v, want the input %#v", got, source)
+	}
+	if got := msg.Header.Get("Order-Id"); got != "7" {
+		t.Errorf("Order-Id header = %q, want 7", got)
+	}
+
+	// String payloads are sent as is
+	node.Payload = "total {{ total }}"
+	if _, err := runNATS(activityContext(t), nc, node, source); err != nil {
+		t.Fatal(err)
+	}
+	if msg, err = sub.NextMsg(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if string(msg.Data) != "total 12.5" {
+		t.Errorf("data = %q, want %q", msg.Data, "total 12.5")
+	}
+}
+
+func TestRunNATSRequest(t *testing.T) {
+	nc := newTestNATS(t)
+	_, err := nc.Subscribe("math.double", func(msg *nats.Msg) {
+		var n float64
+		if v, ok := decodeNATSData(msg.Data).(float64); ok {
+			n = v
+		}
+		reply := nats.NewMsg(msg.Reply)
+		reply.Header.Set("Handled-By", "doubler")
+		reply.Data, _ = natsPayload(map[string]interface{}{"result": n * 2}, nil)
+		msg.RespondMsg(reply)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := models.NATSNodeData{Operation: models.NATSOpRequest, Subject: "math.double", Payload: "{{ n }}"}
+	out, err := runNATS(activityContext(t), nc, node, map[string]interface{}{"n": 21.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"result": 42.0}; !reflect.DeepEqual(out.Data, want) {
+		t.Errorf("data = %#v, want %#v", out.Data, want)
+	}
+	if got := out.Headers["Handled-By"]; !reflect.DeepEqual(got, []string{"doubler"}) {
+		t.Errorf("Handled-By header = %v, want [doubler]", got)
+	}
+}
+
+func TestRunNATSRequestTimeout(t *testing.T) {
+	nc := newTestNATS(t)
+	// A subscriber that never answers
+	if _, err := nc.Subscribe("slow", func(*nats.Msg) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	node := models.NATSNodeData{Operation: models.NATSOpRequest, Subject: "slow", Timeout: "100ms"}
+	start := time.Now()
+	_, err := runNATS(activityContext(t), nc, node, nil)
+	if err == nil || !strings.Contains(err.Error(), "no reply on \"slow\" within 100ms") {
+		t.Fatalf("got %v, want a timeout error", err)
+	}
+	if isNonRetryable(err) {
+		t.Error("timeouts should be retried")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request took %s, want about 100ms", elapsed)
+	}
+
+	node.Subject = "nobody.listens"
+	if _, err := runNATS(activityContext(t), nc, node, nil); !errors.Is(err, nats.ErrNoResponders) {
+		t.Errorf("got %v, want ErrNoResponders", err)
+	}
+}
+
+func TestRunNATSInvalid(t *testing.T) {
+	nc := newTestNATS(t)
+	tests := []models.NATSNodeData{
+		{Operation: models.NATSOpRequest, Subject: "a", Timeout: "soon"},
+		{Operation: models.NATSOpPublish, Subject: "big", Payload: strings.Repeat("x", int(nc.MaxPayload())+1)},
+		{Operation: "stream", Subject: "a"},
+	}
+	for _, node := range tests {
+		if _, err := runNATS(activityContext(t), nc, node, nil); !isNonRetryable(err) {
+			t.Errorf("%s %s: got %v, want a non-retryable error", node.Operation, node.Subject, err)
+		}
+	}
+}
+
+func TestNATSActivityTimeoutCoversRequestTimeout(t *testing.T) {
+	dagStruct := models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "ask", Type: "nats", Data: map[string]interface{}{
+				"operation":  "request",
+				"credential": "event-bus",
+				"subject":    "inventory.reserve",
+				"timeout":    "1m",
+			}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "ask"},
+			{ID: "e2", Source: "ask", Target: "out"},
+		},
+	}
+	env, _ := newWorkflowTestEnv(t, dagStruct)
+	var startToClose time.Duration
+	env.RegisterActivityWithOptions(func(ctx context.Context, _ NATSInput) (*NATSOutput, error) {
+		startToClose = activity.GetInfo(ctx).StartToCloseTimeout
+		return &NATSOutput{}, nil
+	}, activity.RegisterOptions{Name: "NATSActivity"})
+
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+	if startToClose <= time.Minute {
+		t.Errorf("activity StartToCloseTimeout = %s, want more than the 1m request timeout", startToClose)
+	}
+}
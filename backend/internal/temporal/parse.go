@@ -0,0 +1,193 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xmlquery"
+	"go.temporal.io/sdk/temporal"
+	"golang.org/x/net/html"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// ParseInput represents input for the XML/HTML parse activity
+type ParseInput struct {
+	Parse models.ParseNodeData `json:"parse"`
+	Input interface{}          `json:"input"`
+}
+
+// ParseActivity converts an XML document to JSON or extracts named fields
+// from XML or HTML
+func (a *Activities) ParseActivity(ctx context.Context, input ParseInput) (interface{}, error) {
+	node := input.Parse
+	data, err := a.contentAt(ctx, normalizeValue(input.Input), node.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Format {
+	case "xml":
+		doc, err := xmlquery.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid xml: %v", err), "InvalidData", err)
+		}
+		if len(node.Fields) == 0 {
+			return xmlToJSON(doc), nil
+		}
+		return extractXML(doc, node.Fields)
+	case "html":
+		doc, err := htmlquery.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("invalid html: %v", err), "InvalidData", err)
+		}
+		return extractHTML(doc, node.Fields)
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown parse format %q", node.Format), "InvalidData", nil)
+	}
+}
+
+// xmlToJSON converts a document into nested objects. Attributes become
+// "@name" keys, repeated elements become arrays and text next to attributes
+// or child elements is kept under "#text".
+func xmlToJSON(doc *xmlquery.Node) map[string]interface{} {
+	out := make(map[string]interface{})
+	for child := doc.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode {
+			out[xmlName(child.Prefix, child.Data)] = xmlElementValue(child)
+		}
+	}
+	return out
+}
+
+func xmlElementValue(n *xmlquery.Node) interface{} {
+	obj := make(map[string]interface{})
+	for _, attr := range n.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		obj["@"+xmlName(attr.Name.Space, attr.Name.Local)] = attr.Value
+	}
+
+	var text strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			text.WriteString(child.Data)
+		case xmlquery.ElementNode:
+			key := xmlName(child.Prefix, child.Data)
+			value := xmlElementValue(child)
+			switch existing := obj[key].(type) {
+			case nil:
+				obj[key] = value
+			case []interface{}:
+				obj[key] = append(existing, value)
+			default:
+				obj[key] = []interface{}{existing, value}
+			}
+		}
+	}
+
+	trimmed := strings.TrimSpace(text.String())
+	if len(obj) == 0 {
+		return trimmed
+	}
+	if trimmed != "" {
+		obj["#text"] = trimmed
+	}
+	return obj
+}
+
+func xmlName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+func extractXML(doc *xmlquery.Node, fields []models.ExtractField) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		matches, err := xmlquery.QueryAll(doc, field.XPath)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("field %q: invalid xpath: %v", field.Name, err), "InvalidData", err)
+		}
+		values := make([]interface{}, 0, len(matches))
+		for _, m := range matches {
+			switch {
+			case field.Attribute != "":
+				values = append(values, m.SelectAttr(field.Attribute))
+			case field.HTML:
+				values = append(values, m.OutputXML(false))
+			default:
+				values = append(values, collapseSpace(m.InnerText()))
+			}
+		}
+		out[field.Name] = pickMatches(values, field.All)
+	}
+	return out, nil
+}
+
+func extractHTML(doc *html.Node, fields []models.ExtractField) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		var matches []*html.Node
+		if field.CSS != "" {
+			sel, err := cascadia.Compile(field.CSS)
+			if err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("field %q: invalid css selector: %v", field.Name, err), "InvalidData", err)
+			}
+			matches = sel.MatchAll(doc)
+		} else {
+			var err error
+			if matches, err = htmlquery.QueryAll(doc, field.XPath); err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("field %q: invalid xpath: %v", field.Name, err), "InvalidData", err)
+			}
+		}
+
+		values := make([]interface{}, 0, len(matches))
+		for _, m := range matches {
+			switch {
+			case field.Attribute != "":
+				if !htmlquery.ExistsAttr(m, field.Attribute) {
+					values = append(values, nil)
+					continue
+				}
+				values = append(values, htmlquery.SelectAttr(m, field.Attribute))
+			case field.HTML:
+				values = append(values, htmlquery.OutputHTML(m, false))
+			default:
+				values = append(values, collapseSpace(htmlquery.InnerText(m)))
+			}
+		}
+		out[field.Name] = pickMatches(values, field.All)
+	}
+	return out, nil
+}
+
+// pickMatches returns every match when all is set, otherwise the first one
+// (or null)
+func pickMatches(values []interface{}, all bool) interface{} {
+	if all {
+		return values
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+// collapseSpace trims text and folds runs of whitespace left by markup
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,257 @@
+package temporal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// PostgresInput represents input for the Postgres query activity
+type PostgresInput struct {
+	Postgres models.PostgresNodeData `json:"postgres"`
+	Input    interface{}             `json:"input"`
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// PostgresActivity runs the node's statement against the database named by
+// its credential and returns the resulting rows as objects
+func (a *Activities) PostgresActivity(ctx context.Context, input PostgresInput) ([]map[string]interface{}, error) {
+	node := input.Postgres
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypePostgres)
+	if err != nil {
+		return nil, err
+	}
+	client, release, err := a.clients.get(cred, func() (io.Closer, error) {
+		return openPostgres(cred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	db := client.(*sql.DB)
+
+	// One parameter set per statement execution
+	source := normalizeValue(input.Input)
+	var paramSets [][]interface{}
+	if node.Mode == "" || node.Mode == models.PostgresModeSingle {
+		paramSets = [][]interface{}{bindParameters(node.Parameters, source)}
+	} else {
+		items, ok := source.([]interface{})
+		if !ok {
+			items = []interface{}{source}
+		}
+		for _, item := range items {
+			paramSets = append(paramSets, bindParameters(node.Parameters, item))
+		}
+	}
+
+	var q queryer = db
+	var tx *sql.Tx
+	if node.Mode == models.PostgresModeTransaction {
+		tx, err = db.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, classifyPostgresError(err)
+		}
+		defer tx.Rollback()
+		q = tx
+	}
+
+	rows := []map[string]interface{}{}
+	for _, params := range paramSets {
+		limit := -1
+		if node.RowLimit > 0 {
+			limit = node.RowLimit - len(rows)
+		}
+		got, err := queryRows(ctx, q, node.Query, params, limit)
+		if err != nil {
+			return nil, classifyPostgresError(err)
+		}
+		rows = append(rows, got...)
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return nil, classifyPostgresError(err)
+		}
+	}
+	return rows, nil
+}
+
+// postgresReadOnly reports whether query only reads, so retrying it is safe.
+// Anything but a SELECT (without INTO or FOR UPDATE), SHOW, VALUES or TABLE
+// statement counts as a write, including WITH queries, which may contain
+// data-modifying CTEs.
+func postgresReadOnly(query string) bool {
+	fields := strings.Fields(strings.ToUpper(stripSQLComments(query)))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "SELECT", "SHOW", "VALUES", "TABLE":
+		return !slices.Contains(fields, "INTO") && !slices.Contains(fields, "UPDATE")
+	}
+	return false
+}
+
+// stripSQLComments removes leading -- and /* */ comments
+func stripSQLComments(query string) string {
+	for {
+		query = strings.TrimSpace(query)
+		switch {
+		case strings.HasPrefix(query, "--"):
+			_, rest, _ := strings.Cut(query, "\n")
+			query = rest
+		case strings.HasPrefix(query, "/*"):
+			_, rest, ok := strings.Cut(query, "*/")
+			if !ok {
+				return ""
+			}
+			query = rest
+		default:
+			return query
+		}
+	}
+}
+
+// openPostgres builds a connection pool from a credential holding either a
+// connectionString or host/port/database/user/password/sslmode fields
+func openPostgres(cred *models.Credential) (*sql.DB, error) {
+	dsn := credentialString(cred, "connectionString")
+	if dsn == "" {
+		host := credentialString(cred, "host")
+		if host == "" {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has no host or connectionString", cred.Name), "InvalidCredential", nil)
+		}
+		if port := credentialString(cred, "port"); port != "" {
+			host += ":" + port
+		}
+		u := url.URL{
+			Scheme: "postgres",
+			Host:   host,
+			Path:   "/" + credentialString(cred, "database"),
+			User:   url.UserPassword(credentialString(cred, "user"), credentialString(cred, "password")),
+		}
+		if sslMode := credentialString(cred, "sslmode"); sslMode != "" {
+			u.RawQuery = url.Values{"sslmode": {sslMode}}.Encode()
+		}
+		dsn = u.String()
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(5)
+	return db, nil
+}
+
+// bindParameters resolves {{ path }} references against item. Objects and
+// arrays are passed as JSON so they can be bound to json/jsonb columns.
+func bindParameters(params []interface{}, item interface{}) []interface{} {
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		v := resolveValue(p, item)
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			b, err := json.Marshal(v)
+			if err == nil {
+				v = string(b)
+			}
+		}
+		args[i] = v
+	}
+	return args
+}
+
+// queryRows runs query and returns up to limit rows (all when limit is negative).
+// The statement always runs to completion.
+func queryRows(ctx context.Context, q queryer, query string, args []interface{}, limit int) ([]map[string]interface{}, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []map[string]interface{}{}
+	for rows.Next() {
+		if limit >= 0 && len(out) >= limit {
+			continue
+		}
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			row[col.Name()] = columnValue(col, values[i])
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// columnValue converts driver values into JSON-friendly ones
+func columnValue(col *sql.ColumnType, v interface{}) interface{} {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	switch col.DatabaseTypeName() {
+	case "JSON", "JSONB":
+		var parsed interface{}
+		if err := json.Unmarshal(b, &parsed); err == nil {
+			return parsed
+		}
+	case "BYTEA":
+		return b // encoded as base64
+	case "NUMERIC":
+		// Keep full precision unless the value fits a float exactly
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == string(b) {
+			return f
+		}
+	}
+	return string(b)
+}
+
+// classifyPostgresError marks errors that retrying cannot fix (syntax,
+// constraint violations, permissions) as non-retryable
+func classifyPostgresError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code.Class() {
+	case "08", "40", "53", "57", "58":
+		// Connection problems, serialization failures, resource limits
+		return err
+	}
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("%s (SQLSTATE %s)", pqErr.Message, pqErr.Code), "PostgresError", err)
+}
@@ -0,0 +1,67 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestPostgresReadOnly(t *testing.T) {
+	tests := map[string]bool{
+		"SELECT * FROM orders WHERE id = $1":                   true,
+		"  -- latest\n/* report */ select count(*) FROM users": true,
+		"SHOW server_version":                                  true,
+		"VALUES (1), (2)":                                      true,
+		"INSERT INTO orders (id) VALUES ($1)":                  false,
+		"update orders SET paid = true":                        false,
+		"DELETE FROM sessions":                                 false,
+		"WITH moved AS (DELETE FROM a RETURNING *) SELECT 1":   false,
+		"SELECT * INTO archive FROM orders":                    false,
+		"SELECT * FROM jobs FOR UPDATE SKIP LOCKED":            false,
+		"/* unterminated":                                      false,
+		"":                                                     false,
+	}
+	for query, want := range tests {
+		if got := postgresReadOnly(query); got != want {
+			t.Errorf("postgresReadOnly(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestLoadCredentialResolvesIDsAndNames(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	a := &Activities{DB: db}
+
+	data, _ := json.Marshal(map[string]string{"host": "db"})
+	columns := []string{"id", "name", "type", "data", "created_at", "updated_at"}
+	id := "0b7c7a52-5b2a-4c1e-9a57-3f0d6c1b2a10"
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM credentials WHERE id = $1")).WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(id, "reporting", models.CredentialTypePostgres, data, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM credentials WHERE name = $1")).WithArgs("reporting").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(id, "reporting", models.CredentialTypePostgres, data, now, now))
+
+	for _, ref := range []string{id, "reporting"} {
+		cred, err := a.loadCredential(context.Background(), ref, models.CredentialTypePostgres)
+		if err != nil {
+			t.Fatalf("%s: %v", ref, err)
+		}
+		if cred.ID != id {
+			t.Errorf("%s: loaded %s, want %s", ref, cred.ID, id)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
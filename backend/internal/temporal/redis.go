@@ -0,0 +1,170 @@
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// RedisInput represents input for the Redis activity
+type RedisInput struct {
+	Redis models.RedisNodeData `json:"redis"`
+	Input interface{}          `json:"input"`
+}
+
+// RedisOutput represents output from the Redis activity
+type RedisOutput struct {
+	Value interface{} `json:"value"`
+}
+
+// RedisActivity runs one Redis command using a client pooled per credential.
+// Values read back are returned as stored; missing keys yield null.
+func (a *Activities) RedisActivity(ctx context.Context, input RedisInput) (*RedisOutput, error) {
+	node := input.Redis
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeRedis)
+	if err != nil {
+		return nil, err
+	}
+	client, release, err := a.clients.get(cred, func() (io.Closer, error) {
+		return openRedis(cred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return runRedisCommand(ctx, client.(*redis.Client), node, normalizeValue(input.Input))
+}
+
+// redisReadOnly reports whether op only reads, so retrying it is safe
+func redisReadOnly(op string) bool {
+	switch op {
+	case models.RedisOpGet, models.RedisOpHGet, models.RedisOpHGetAll:
+		return true
+	}
+	return false
+}
+
+// runRedisCommand executes the node's operation with its fields resolved
+// against source
+func runRedisCommand(ctx context.Context, rdb *redis.Client, node models.RedisNodeData, source interface{}) (*RedisOutput, error) {
+	key := stringify(resolveTemplate(node.Key, source))
+	field := stringify(resolveTemplate(node.Field, source))
+	value := stringify(resolveValue(node.Value, source))
+
+	var result interface{}
+	var err error
+	switch node.Operation {
+	case models.RedisOpGet:
+		result, err = rdb.Get(ctx, key).Result()
+	case models.RedisOpSet:
+		var ttl time.Duration
+		if node.TTL != "" {
+			if ttl, err = time.ParseDuration(node.TTL); err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("invalid ttl %q", node.TTL), "InvalidData", err)
+			}
+		}
+		if node.IfNotExists {
+			result, err = rdb.SetNX(ctx, key, value, ttl).Result()
+		} else {
+			err = rdb.Set(ctx, key, value, ttl).Err()
+			result = true
+		}
+	case models.RedisOpDelete:
+		result, err = rdb.Del(ctx, key).Result()
+	case models.RedisOpIncr:
+		by := node.By
+		if by == 0 {
+			by = 1
+		}
+		result, err = rdb.IncrBy(ctx, key, by).Result()
+	case models.RedisOpHGet:
+		result, err = rdb.HGet(ctx, key, field).Result()
+	case models.RedisOpHSet:
+		result, err = rdb.HSet(ctx, key, field, value).Result()
+	case models.RedisOpHGetAll:
+		var fields map[string]string
+		fields, err = rdb.HGetAll(ctx, key).Result()
+		if err == nil {
+			obj := make(map[string]interface{}, len(fields))
+			for k, v := range fields {
+				obj[k] = v
+			}
+			result = obj
+		}
+	case models.RedisOpHDel:
+		result, err = rdb.HDel(ctx, key, field).Result()
+	case models.RedisOpLPush:
+		result, err = rdb.LPush(ctx, key, value).Result()
+	case models.RedisOpRPop:
+		result, err = rdb.RPop(ctx, key).Result()
+	case models.RedisOpPublish:
+		channel := stringify(resolveTemplate(node.Channel, source))
+		result, err = rdb.Publish(ctx, channel, value).Result()
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown redis operation %q", node.Operation), "InvalidData", nil)
+	}
+
+	if errors.Is(err, redis.Nil) {
+		return &RedisOutput{Value: nil}, nil
+	}
+	if err != nil {
+		// Server replies such as WRONGTYPE will not change on retry
+		var redisErr redis.Error
+		if errors.As(err, &redisErr) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "RedisError", err)
+		}
+		return nil, err
+	}
+	return &RedisOutput{Value: result}, nil
+}
+
+// openRedis builds a pooled client from a credential holding either a url
+// (redis://...) or address/username/password/db/tls fields. The client never
+// retries by itself: a command whose reply was lost may already have run, and
+// retrying is left to the activity's retry policy, which skips writes.
+func openRedis(cred *models.Credential) (*redis.Client, error) {
+	if u := credentialString(cred, "url"); u != "" {
+		opts, err := redis.ParseURL(u)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has an invalid url", cred.Name), "InvalidCredential", err)
+		}
+		opts.MaxRetries = -1
+		return redis.NewClient(opts), nil
+	}
+
+	address := credentialString(cred, "address")
+	if address == "" {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q has no address or url", cred.Name), "InvalidCredential", nil)
+	}
+	opts := &redis.Options{
+		Addr:       address,
+		Username:   credentialString(cred, "username"),
+		Password:   credentialString(cred, "password"),
+		MaxRetries: -1,
+	}
+	if db := credentialString(cred, "db"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("credential %q has an invalid db", cred.Name), "InvalidCredential", err)
+		}
+		opts.DB = n
+	}
+	if useTLS, _ := strconv.ParseBool(credentialString(cred, "tls")); useTLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return redis.NewClient(opts), nil
+}
@@ -0,0 +1,202 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return mr, rdb
+}
+
+func TestRunRedisCommand(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	ctx := context.Background()
+	source := map[string]interface{}{"id": "42", "name": "Ada"}
+
+	steps := []struct {
+		node models.RedisNodeData
+		want interface{}
+	}{
+		{node: models.RedisNodeData{Operation: models.RedisOpGet, Key: "user:{{ id }}"}, want: nil},
+		{node: models.RedisNodeData{Operation: models.RedisOpSet, Key: "user:{{ id }}", Value: "{{ name }}"}, want: true},
+		{node: models.RedisNodeData{Operation: models.RedisOpSet, Key: "user:{{ id }}", Value: "x", IfNotExists: true}, want: false},
+		{node: models.RedisNodeData{Operation: models.RedisOpGet, Key: "user:{{ id }}"}, want: "Ada"},
+		{node: models.RedisNodeData{Operation: models.RedisOpIncr, Key: "count", By: 5}, want: int64(5)},
+		{node: models.RedisNodeData{Operation: models.RedisOpIncr, Key: "count"}, want: int64(6)},
+		{node: models.RedisNodeData{Operation: models.RedisOpHSet, Key: "h", Field: "a", Value: "1"}, want: int64(1)},
+		{node: models.RedisNodeData{Operation: models.RedisOpHGet, Key: "h", Field: "a"}, want: "1"},
+		{node: models.RedisNodeData{Operation: models.RedisOpHGetAll, Key: "h"}, want: map[string]interface{}{"a": "1"}},
+		{node: models.RedisNodeData{Operation: models.RedisOpHDel, Key: "h", Field: "a"}, want: int64(1)},
+		{node: models.RedisNodeData{Operation: models.RedisOpLPush, Key: "q", Value: "job"}, want: int64(1)},
+		{node: models.RedisNodeData{Operation: models.RedisOpRPop, Key: "q"}, want: "job"},
+		{node: models.RedisNodeData{Operation: models.RedisOpRPop, Key: "q"}, want: nil},
+		{node: models.RedisNodeData{Operation: models.RedisOpPublish, Channel: "events", Value: "hi"}, want: int64(0)},
+		{node: models.RedisNodeData{Operation: models.RedisOpDelete, Key: "user:{{ id }}"}, want: int64(1)},
+	}
+	for i, step := range steps {
+		out, err := runRedisCommand(ctx, rdb, step.node, source)
+		if err != nil {
+			t.Fatalf("step %d (%s): %v", i, step.node.Operation, err)
+		}
+		if !reflect.DeepEqual(out.Value, step.want) {
+			t.Errorf("step %d (%s): got %#v, want %#v", i, step.node.Operation, out.Value, step.want)
+		}
+	}
+	if mr.Exists("user:42") {
+		t.Error("user:42 still exists after delete")
+	}
+}
+
+func TestRunRedisCommandTTL(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	node := models.RedisNodeData{Operation: models.RedisOpSet, Key: "k", Value: "v", TTL: "1m"}
+	if _, err := runRedisCommand(context.Background(), rdb, node, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := mr.TTL("k"); ttl.Minutes() != 1 {
+		t.Errorf("ttl = %s, want 1m", ttl)
+	}
+}
+
+func TestRunRedisCommandErrorsAreNotRetried(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	ctx := context.Background()
+	if _, err := runRedisCommand(ctx, rdb, models.RedisNodeData{Operation: models.RedisOpLPush, Key: "l", Value: "x"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []models.RedisNodeData{
+		{Operation: models.RedisOpGet, Key: "l"}, // WRONGTYPE
+		{Operation: models.RedisOpSet, Key: "k", TTL: "soon"},
+		{Operation: "flushall"},
+	}
+	for _, node := range tests {
+		_, err := runRedisCommand(ctx, rdb, node, nil)
+		if !isNonRetryable(err) {
+			t.Errorf("%+v: got %v, want a non-retryable error", node, err)
+		}
+	}
+}
+
+func TestRedisReadOnly(t *testing.T) {
+	for _, op := range []string{models.RedisOpGet, models.RedisOpHGet, models.RedisOpHGetAll} {
+		if !redisReadOnly(op) {
+			t.Errorf("%s should be read-only", op)
+		}
+	}
+	for _, op := range []string{models.RedisOpSet, models.RedisOpIncr, models.RedisOpLPush, models.RedisOpRPop, models.RedisOpPublish} {
+		if redisReadOnly(op) {
+			t.Errorf("%s should not be read-only", op)
+		}
+	}
+}
+
+// isNonRetryable reports whether err is an application error Temporal will
+// not retry
+func isNonRetryable(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.NonRetryable()
+}
+
+// dropReplyProxy forwards connections to addr, but closes the first client
+// connection that sends match as soon as its reply arrives, so the command
+// runs on the server while the client sees the connection drop
+func dropReplyProxy(t *testing.T, addr string, match []byte) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	var dropped atomic.Bool
+	go func() {
+		for {
+			client, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			server, err := net.Dial("tcp", addr)
+			if err != nil {
+				client.Close()
+				return
+			}
+			var armed atomic.Bool
+			go func() {
+				buf := make([]byte, 4096)
+				for {
+					n, err := client.Read(buf)
+					if n > 0 {
+						if bytes.Contains(bytes.ToUpper(buf[:n]), match) && !dropped.Load() {
+							armed.Store(true)
+						}
+						server.Write(buf[:n])
+					}
+					if err != nil {
+						server.Close()
+						return
+					}
+				}
+			}()
+			go func() {
+				buf := make([]byte, 4096)
+				for {
+					n, err := server.Read(buf)
+					if n > 0 && armed.Load() && dropped.CompareAndSwap(false, true) {
+						client.Close()
+						server.Close()
+						return
+					}
+					if n > 0 {
+						client.Write(buf[:n])
+					}
+					if err != nil {
+						client.Close()
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestOpenRedisDoesNotRetryLostReplies(t *testing.T) {
+	mr := miniredis.RunT(t)
+	creds := map[string]func(addr string) map[string]interface{}{
+		"address": func(addr string) map[string]interface{} { return map[string]interface{}{"address": addr} },
+		"url":     func(addr string) map[string]interface{} { return map[string]interface{}{"url": "redis://" + addr} },
+	}
+	for name, data := range creds {
+		mr.FlushAll()
+		proxy := dropReplyProxy(t, mr.Addr(), []byte("INCRBY"))
+		rdb, err := openRedis(&models.Credential{Name: name, Data: data(proxy)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = runRedisCommand(context.Background(), rdb, models.RedisNodeData{Operation: models.RedisOpIncr, Key: "count"}, nil)
+		rdb.Close()
+		if err == nil {
+			t.Errorf("%s: incr succeeded although its reply was dropped", name)
+		}
+		if got, _ := mr.Get("count"); got != "1" {
+			t.Errorf("%s: count = %q after one incr, want 1", name, got)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	miniocreds "github.com/minio/minio-go/v7/pkg/credentials"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// maxS3DownloadBytes caps objects downloaded into binary storage
+const maxS3DownloadBytes = 100 << 20
+
+// S3Input represents input for the S3 activity
+type S3Input struct {
+	S3    models.S3NodeData `json:"s3"`
+	Input interface{}       `json:"input"`
+}
+
+// S3Output represents output from the S3 activity; fields are set per
+// operation
+type S3Output struct {
+	Bucket      string            `json:"bucket"`
+	Key         string            `json:"key,omitempty"`
+	ETag        string            `json:"etag,omitempty"`
+	Size        int64             `json:"size,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	File        *models.BinaryRef `json:"file,omitempty"`     // get
+	Objects     []S3Object        `json:"objects,omitempty"`  // list
+	Prefixes    []string          `json:"prefixes,omitempty"` // list, when not recursive
+	URL         string            `json:"url,omitempty"`      // presign
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	Deleted     bool              `json:"deleted,omitempty"`
+}
+
+// S3Object describes one listed object
+type S3Object struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// S3Activity puts, gets, lists, deletes or presigns objects on an
+// S3-compatible endpoint
+func (a *Activities) S3Activity(ctx context.Context, input S3Input) (*S3Output, error) {
+	node := input.S3
+	source := normalizeValue(input.Input)
+
+	cred, err := a.loadCredential(ctx, node.Credential, models.CredentialTypeS3)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newS3Client(cred, node)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := stringify(resolveTemplate(node.Bucket, source))
+	key := stringify(resolveTemplate(node.Key, source))
+	output := &S3Output{Bucket: bucket, Key: key}
+
+	switch node.Operation {
+	case models.S3OpPut:
+		data, contentType, err := a.contentWithType(ctx, source, node.Path)
+		if err != nil {
+			return nil, err
+		}
+		if node.ContentType != "" {
+			contentType = node.ContentType
+		}
+		if contentType == "" {
+			contentType = storage.DetectMimeType(key, data)
+		}
+		info, err := client.PutObject(ctx, bucket, key, bytes.NewReader(data), int64(len(data)),
+			minio.PutObjectOptions{ContentType: contentType})
+		if err != nil {
+			return nil, classifyS3Error(err)
+		}
+		output.ETag, output.Size, output.ContentType = info.ETag, info.Size, contentType
+	case models.S3OpGet:
+		obj, err := client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, classifyS3Error(err)
+		}
+		defer obj.Close()
+		info, err := obj.Stat()
+		if err != nil {
+			return nil, classifyS3Error(err)
+		}
+		if info.Size > maxS3DownloadBytes {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("object is %d bytes, larger than the %d byte limit", info.Size, maxS3DownloadBytes), "InvalidData", nil)
+		}
+		data, err := io.ReadAll(obj)
+		if err != nil {
+			return nil, classifyS3Error(err)
+		}
+		ref, err := storage.NewBinaryStore(a.DB).Put(ctx, path.Base(key), info.ContentType, data)
+		if err != nil {
+			return nil, err
+		}
+		output.File, output.ETag, output.Size, output.ContentType = ref, info.ETag, info.Size, ref.MimeType
+	case models.S3OpList:
+		maxKeys := node.MaxKeys
+		if maxKeys == 0 {
+			maxKeys = 1000
+		}
+		output.Key = ""
+		output.Objects = []S3Object{}
+		opts := minio.ListObjectsOptions{Prefix: stringify(resolveTemplate(node.Prefix, source)), Recursive: node.Recursive}
+		listCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		for obj := range client.ListObjects(listCtx, bucket, opts) {
+			if obj.Err != nil {
+				return nil, classifyS3Error(obj.Err)
+			}
+			if !node.Recursive && strings.HasSuffix(obj.Key, "/") && obj.Size == 0 && obj.ETag == "" {
+				output.Prefixes = append(output.Prefixes, obj.Key)
+				continue
+			}
+			output.Objects = append(output.Objects, S3Object{
+				Key:          obj.Key,
+				Size:         obj.Size,
+				ETag:         obj.ETag,
+				LastModified: obj.LastModified,
+			})
+			if len(output.Objects) >= maxKeys {
+				break
+			}
+		}
+	case models.S3OpDelete:
+		if err := client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{}); err != nil {
+			return nil, classifyS3Error(err)
+		}
+		output.Deleted = true
+	case models.S3OpPresign:
+		expires := 15 * time.Minute
+		if node.Expires != "" {
+			if expires, err = time.ParseDuration(node.Expires); err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("invalid expires %q", node.Expires), "InvalidData", err)
+			}
+		}
+		var u *url.URL
+		if node.PresignMethod == http.MethodPut {
+			u, err = client.PresignedPutObject(ctx, bucket, key, expires)
+		} else {
+			u, err = client.PresignedGetObject(ctx, bucket, key, expires, nil)
+		}
+		if err != nil {
+			return nil, classifyS3Error(err)
+		}
+		expiresAt := time.Now().UTC().Add(expires)
+		output.URL, output.ExpiresAt = u.String(), &expiresAt
+	default:
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("unknown s3 operation %q", node.Operation), "InvalidData", nil)
+	}
+	return output, nil
+}
+
+// newS3Client builds a client from an s3 credential holding accessKeyId,
+// secretAccessKey and optionally sessionToken, region, endpoint and pathStyle.
+// Endpoints without a scheme use HTTPS.
+func newS3Client(cred *models.Credential, node models.S3NodeData) (*minio.Client, error) {
+	endpoint := node.Endpoint
+	if endpoint == "" {
+		endpoint = credentialString(cred, "endpoint")
+	}
+	if endpoint == "" {
+		endpoint = "https://s3.amazonaws.com"
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid s3 endpoint %q", endpoint), "InvalidData", err)
+	}
+
+	region := node.Region
+	if region == "" {
+		region = credentialString(cred, "region")
+	}
+	lookup := minio.BucketLookupAuto
+	pathStyle, _ := strconv.ParseBool(credentialString(cred, "pathStyle"))
+	if node.PathStyle || pathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds: miniocreds.NewStaticV4(
+			credentialString(cred, "accessKeyId"),
+			credentialString(cred, "secretAccessKey"),
+			credentialString(cred, "sessionToken")),
+		Secure:       u.Scheme == "https",
+		Region:       region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("credential %q: %v", cred.Name, err), "InvalidCredential", err)
+	}
+	return client, nil
+}
+
+// classifyS3Error marks client errors (missing keys or buckets, denied
+// access) as non-retryable
+func classifyS3Error(err error) error {
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		msg := resp.Message
+		if resp.Code != "" {
+			msg = resp.Code + ": " + msg
+		}
+		return temporal.NewNonRetryableApplicationError(msg, "S3Error", err)
+	}
+	return err
+}
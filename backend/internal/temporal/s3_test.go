@@ -0,0 +1,299 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// fakeS3 serves path-style S3 requests for one bucket from memory
+type fakeS3 struct {
+	bucket string
+
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	data        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		s3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if r.Header.Get("Authorization") == "" {
+		s3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPut && key != "":
+		data, err := io.ReadAll(r.Body)
+		if err == nil && strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			data, err = decodeAWSChunked(data)
+		}
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[key] = fakeS3Object{data: data, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", etagOf(data))
+	case (r.Method == http.MethodGet || r.Method == http.MethodHead) && key != "":
+		obj, ok := f.objects[key]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", etagOf(obj.data))
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", fmt.Sprint(len(obj.data)))
+		w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+	case r.Method == http.MethodGet:
+		f.list(w, r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter"))
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// list answers ListObjectsV2 in a single page
+func (f *fakeS3) list(w http.ResponseWriter, prefix, delimiter string) {
+	type content struct {
+		Key          string
+		Size         int64
+		ETag         string
+		LastModified string
+	}
+	type commonPrefix struct {
+		Prefix string
+	}
+	result := struct {
+		XMLName        xml.Name `xml:"ListBucketResult"`
+		Name           string
+		Prefix         string
+		KeyCount       int
+		IsTruncated    bool
+		Contents       []content
+		CommonPrefixes []commonPrefix
+	}{Name: f.bucket, Prefix: prefix}
+
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if i := strings.Index(rest, delimiter); delimiter != "" && i >= 0 {
+			if p := prefix + rest[:i+len(delimiter)]; !seen[p] {
+				seen[p] = true
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{p})
+			}
+			continue
+		}
+		data := f.objects[key].data
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			Size:         int64(len(data)),
+			ETag:         etagOf(data),
+			LastModified: time.Unix(0, 0).UTC().Format(time.RFC3339),
+		})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+// decodeAWSChunked strips the chunk framing of streaming signed uploads:
+// "<hex size>;chunk-signature=...\r\n<data>\r\n", ending with a zero size
+func decodeAWSChunked(body []byte) ([]byte, error) {
+	var data []byte
+	for {
+		header, rest, ok := strings.Cut(string(body), "\r\n")
+		if !ok {
+			return nil, io.ErrUnexpectedEOF
+		}
+		sizeHex, _, _ := strings.Cut(header, ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil || int64(len(rest)) < size {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if size == 0 {
+			return data, nil
+		}
+		data = append(data, rest[:size]...)
+		body = []byte(strings.TrimPrefix(rest[size:], "\r\n"))
+	}
+}
+
+func s3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func etagOf(data []byte) string {
+	return fmt.Sprintf(`"%x"`, len(data))
+}
+
+// newS3Test starts a fake S3 endpoint and activities whose database holds an
+// s3 credential named "store"
+func newS3Test(t *testing.T) (*Activities, sqlmock.Sqlmock, *fakeS3, models.S3NodeData) {
+	t.Helper()
+	fake := &fakeS3{bucket: "reports", objects: make(map[string]fakeS3Object)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	a := &Activities{DB: db}
+
+	node := models.S3NodeData{Credential: "store", Endpoint: srv.URL, PathStyle: true, Bucket: "reports"}
+	return a, mock, fake, node
+}
+
+func expectS3Credential(mock sqlmock.Sqlmock) {
+	data, _ := json.Marshal(map[string]string{"accessKeyId": "key", "secretAccessKey": "secret", "region": "us-east-1"})
+	now := time.Now()
+	mock.ExpectQuery("FROM credentials").WithArgs("store").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "type", "data", "created_at", "updated_at"}).
+			AddRow("cred-1", "store", models.CredentialTypeS3, data, now, now))
+}
+
+func TestS3ActivityPutGetListDelete(t *testing.T) {
+	a, mock, fake, node := newS3Test(t)
+	ctx := context.Background()
+	source := map[string]interface{}{"name": "q1", "body": "revenue,12"}
+
+	expectS3Credential(mock)
+	put := node
+	put.Operation, put.Key, put.Path = models.S3OpPut, "2024/{{ name }}.csv", "body"
+	out, err := a.S3Activity(ctx, S3Input{S3: put, Input: source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Key != "2024/q1.csv" || out.Size != 10 || out.ContentType != "text/csv; charset=utf-8" {
+		t.Errorf("put output = %+v", out)
+	}
+	if got := string(fake.objects["2024/q1.csv"].data); got != "revenue,12" {
+		t.Errorf("stored %q, want the input body", got)
+	}
+	fake.objects["readme.txt"] = fakeS3Object{data: []byte("hi"), contentType: "text/plain"}
+
+	expectS3Credential(mock)
+	mock.ExpectExec("INSERT INTO binary_data").
+		WithArgs(sqlmock.AnyArg(), "q1.csv", "text/csv; charset=utf-8", int64(10), []byte("revenue,12"), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	get := node
+	get.Operation, get.Key = models.S3OpGet, "2024/q1.csv"
+	out, err = a.S3Activity(ctx, S3Input{S3: get})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.File == nil || out.File.FileName != "q1.csv" || out.Size != 10 {
+		t.Errorf("get output = %+v", out)
+	}
+
+	expectS3Credential(mock)
+	list := node
+	list.Operation = models.S3OpList
+	out, err = a.S3Activity(ctx, S3Input{S3: list})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Objects) != 1 || out.Objects[0].Key != "readme.txt" || len(out.Prefixes) != 1 || out.Prefixes[0] != "2024/" {
+		t.Errorf("list output = %+v", out)
+	}
+
+	expectS3Credential(mock)
+	list.Recursive = true
+	if out, err = a.S3Activity(ctx, S3Input{S3: list}); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Objects) != 2 || len(out.Prefixes) != 0 {
+		t.Errorf("recursive list output = %+v", out)
+	}
+
+	expectS3Credential(mock)
+	del := node
+	del.Operation, del.Key = models.S3OpDelete, "readme.txt"
+	if out, err = a.S3Activity(ctx, S3Input{S3: del}); err != nil || !out.Deleted {
+		t.Fatalf("delete: %+v, %v", out, err)
+	}
+	if _, ok := fake.objects["readme.txt"]; ok {
+		t.Error("readme.txt still exists after delete")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestS3ActivityPresign(t *testing.T) {
+	a, mock, _, node := newS3Test(t)
+	expectS3Credential(mock)
+	node.Operation, node.Key, node.Expires, node.PresignMethod = models.S3OpPresign, "report.pdf", "1h", http.MethodPut
+	out, err := a.S3Activity(context.Background(), S3Input{S3: node})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.URL, "/reports/report.pdf?") || !strings.Contains(out.URL, "X-Amz-Expires=3600") {
+		t.Errorf("url = %s", out.URL)
+	}
+	if out.ExpiresAt == nil || time.Until(*out.ExpiresAt) < 59*time.Minute {
+		t.Errorf("expiresAt = %v, want about an hour from now", out.ExpiresAt)
+	}
+}
+
+func TestS3ActivityClientErrorsAreNotRetried(t *testing.T) {
+	a, mock, _, node := newS3Test(t)
+	tests := []models.S3NodeData{
+		{Operation: models.S3OpGet, Key: "missing.txt"},
+		{Operation: models.S3OpList, Bucket: "other"},
+		{Operation: models.S3OpPresign, Key: "a", Expires: "soon"},
+		{Operation: "copy", Key: "a"},
+	}
+	for _, tt := range tests {
+		expectS3Credential(mock)
+		n := node
+		n.Operation, n.Key, n.Expires = tt.Operation, tt.Key, tt.Expires
+		if tt.Bucket != "" {
+			n.Bucket = tt.Bucket
+		}
+		if _, err := a.S3Activity(context.Background(), S3Input{S3: n}); !isNonRetryable(err) {
+			t.Errorf("%s %s/%s: got %v, want a non-retryable error", n.Operation, n.Bucket, n.Key, err)
+		}
+	}
+}
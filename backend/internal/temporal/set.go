@@ -0,0 +1,133 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// SetFieldsInput represents input for the set node activity
+type SetFieldsInput struct {
+	Assignments []models.SetAssignment `json:"assignments"`
+	KeepOnlySet bool                   `json:"keep_only_set"`
+	Input       interface{}            `json:"input"`
+}
+
+// SetFieldsActivity builds the set node output from its assignments. Unless
+// KeepOnlySet is true the assignments are merged into a copy of the input.
+func (a *Activities) SetFieldsActivity(ctx context.Context, input SetFieldsInput) (interface{}, error) {
+	source := normalizeValue(input.Input)
+
+	output := make(map[string]interface{})
+	if !input.KeepOnlySet {
+		if obj, ok := source.(map[string]interface{}); ok {
+			output = deepCopyMap(obj)
+		}
+	}
+
+	for _, assignment := range input.Assignments {
+		value := resolveValue(assignment.Value, source)
+		converted, err := convertType(value, assignment.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", assignment.Name, err)
+		}
+		if err := setPath(output, assignment.Name, converted); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// convertType coerces a resolved value into the requested JSON type
+func convertType(v interface{}, t string) (interface{}, error) {
+	switch t {
+	case "":
+		return v, nil
+	case "string":
+		return stringify(v), nil
+	case "number":
+		switch val := v.(type) {
+		case float64:
+			return val, nil
+		case bool:
+			if val {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %q to number", val)
+			}
+			return f, nil
+		case nil:
+			return nil, nil
+		}
+	case "boolean", "bool":
+		switch val := v.(type) {
+		case bool:
+			return val, nil
+		case float64:
+			return val != 0, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(val))
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert %q to boolean", val)
+			}
+			return b, nil
+		case nil:
+			return false, nil
+		}
+	case "object", "array":
+		if s, ok := v.(string); ok {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+				return nil, fmt.Errorf("cannot parse %s: %v", t, err)
+			}
+			v = parsed
+		}
+		switch v.(type) {
+		case map[string]interface{}:
+			if t == "object" {
+				return v, nil
+			}
+		case []interface{}:
+			if t == "array" {
+				return v, nil
+			}
+		case nil:
+			return nil, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", t)
+	}
+	return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+}
+
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = deepCopyValue(v)
+	}
+	return out
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = deepCopyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
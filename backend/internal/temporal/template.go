@@ -0,0 +1,74 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+	"github.com/your-org/n8n-clone/pkg/dag"
+)
+
+// maxTemplateOutputBytes caps the rendered output of a template node
+const maxTemplateOutputBytes = 10 << 20
+
+// TemplateInput represents input for the template rendering activity
+type TemplateInput struct {
+	Template models.TemplateNodeData `json:"template"`
+	Input    interface{}             `json:"input"`
+}
+
+// TemplateOutput represents output from the template rendering activity
+type TemplateOutput struct {
+	Text string            `json:"text,omitempty"`
+	File *models.BinaryRef `json:"file,omitempty"`
+}
+
+// TemplateActivity renders the node's Go template against its input
+func (a *Activities) TemplateActivity(ctx context.Context, input TemplateInput) (*TemplateOutput, error) {
+	node := input.Template
+	tmpl, err := dag.ParseTemplate(node.Engine, node.Template)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("invalid template: %v", err), "InvalidData", err)
+	}
+
+	buf := &limitedBuffer{limit: maxTemplateOutputBytes}
+	if err := tmpl.Execute(buf, normalizeValue(input.Input)); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("template execution failed: %v", err), "TemplateError", err)
+	}
+
+	if node.Output != "file" {
+		return &TemplateOutput{Text: buf.String()}, nil
+	}
+	fileName := node.FileName
+	if fileName == "" {
+		fileName = "output.txt"
+		if node.Engine == "html" {
+			fileName = "output.html"
+		}
+	}
+	ref, err := storage.NewBinaryStore(a.DB).Put(ctx, fileName, node.MimeType, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateOutput{File: ref}, nil
+}
+
+// limitedBuffer fails writes that would grow it past limit, which stops the
+// template mid-render
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("output exceeds %d bytes", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,35 @@
+package temporal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestTemplateActivityRenders(t *testing.T) {
+	a := &Activities{}
+	out, err := a.TemplateActivity(context.Background(), TemplateInput{
+		Template: models.TemplateNodeData{Template: `{{ range .rows }}{{ .name | upper }};{{ end }}`},
+		Input:    map[string]interface{}{"rows": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Text != "A;B;" {
+		t.Errorf("text = %q, want A;B;", out.Text)
+	}
+}
+
+func TestTemplateActivityOutputLimit(t *testing.T) {
+	a := &Activities{}
+	items := make([]interface{}, maxTemplateOutputBytes/(1<<20)+1)
+	_, err := a.TemplateActivity(context.Background(), TemplateInput{
+		Template: models.TemplateNodeData{Template: `{{ range .items }}{{ $.big }}{{ end }}`},
+		Input:    map[string]interface{}{"items": items, "big": strings.Repeat("x", 1<<20)},
+	})
+	if !isNonRetryable(err) || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("got %v, want a non-retryable output size error", err)
+	}
+}
@@ -2,6 +2,7 @@ package temporal
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,8 +16,9 @@ import (
 
 // WorkflowInput represents input to the workflow
 type WorkflowInput struct {
-	WorkflowID  string `json:"workflow_id"`
-	ExecutionID string `json:"execution_id"`
+	WorkflowID  string      `json:"workflow_id"`
+	ExecutionID string      `json:"execution_id"`
+	Payload     interface{} `json:"payload,omitempty"` // Event data from the trigger that started the run
 }
 
 // WorkflowResult represents the workflow execution result
@@ -26,16 +28,17 @@ type WorkflowResult struct {
 	Error       string      `json:"error,omitempty"`
 }
 
+// edgeInputChange marks runs that feed each node the output of its incoming
+// edge and reject edges from outputs their source does not emit. Runs started
+// before it hand every node the previous node's output when replayed.
+const edgeInputChange = "edge-input"
+
 // DAGWorkflow executes a workflow DAG
 func DAGWorkflow(ctx workflow.Context, input WorkflowInput) (*WorkflowResult, error) {
+	version := workflow.GetVersion(ctx, edgeInputChange, workflow.DefaultVersion, 1)
+
 	// Set activity options
-	ao := workflow.ActivityOptions{
-		StartToCloseTimeout: 30 * time.Second,
-		RetryPolicy: &temporal.RetryPolicy{
-			MaximumAttempts: 3,
-		},
-	}
-	activityCtx := workflow.WithActivityOptions(ctx, ao)
+	activityCtx := workflow.WithActivityOptions(ctx, defaultActivityOptions())
 
 	result := &WorkflowResult{
 		ExecutionID: input.ExecutionID,
@@ -55,6 +58,9 @@ func DAGWorkflow(ctx workflow.Context, input WorkflowInput) (*WorkflowResult, er
 
 	// Validate DAG
 	validation := dag.ValidateDAG(&dagStruct)
+	if version == workflow.DefaultVersion {
+		validation = dag.ValidateDAGWithoutSourceHandles(&dagStruct)
+	}
 	if !validation.Valid {
 		// mark failed with error message
 		errMsg := fmt.Sprintf("dag validation failed: %v", validation.Errors)
@@ -74,76 +80,45 @@ func DAGWorkflow(ctx workflow.Context, input WorkflowInput) (*WorkflowResult, er
 	}
 
 	var lastResult interface{}
+	results := make(map[string]nodeResult)
+	position := make(map[string]int)
 
 	// Execute nodes in order
-	for _, nodeID := range order {
+	for i, nodeID := range order {
 		node := dag.GetNodeByID(nodeID, dagStruct.Nodes)
 		if node == nil {
 			continue
 		}
+		// Batch bodies have already run as part of their batch node
+		if _, done := results[node.ID]; done {
+			continue
+		}
 
-		switch node.Type {
-		case "start":
-			lastResult = map[string]interface{}{"start": node.ID}
-		case "http":
-			httpData, err := parseHTTPData(node.Data)
-			if err != nil {
-				errMsg := fmt.Sprintf("failed to parse HTTP node data: %v", err)
-				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, errMsg).Get(activityCtx, nil)
-				return nil, err
-			}
-			var httpResp HttpRequestOutput
-			err = workflow.ExecuteActivity(activityCtx, (*Activities).HttpRequestActivity, HttpRequestInput{
-				Method:  httpData.Method,
-				URL:     httpData.URL,
-				Headers: httpData.Headers,
-				Query:   httpData.Query,
-				Body:    httpData.Body,
-			}).Get(activityCtx, &httpResp)
-			if err != nil {
-				errMsg := fmt.Sprintf("HTTP request failed: %v", err)
-				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, errMsg).Get(activityCtx, nil)
-				return nil, err
-			}
-			lastResult = httpResp
-		case "code":
-			codeData, err := parseCodeData(node.Data)
-			if err != nil {
-				errMsg := fmt.Sprintf("failed to parse code node data: %v", err)
-				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, errMsg).Get(activityCtx, nil)
-				return nil, err
-			}
-			
-			// If code is empty, passthrough
-			if codeData.Code == "" || strings.TrimSpace(codeData.Code) == "" {
-				// Passthrough: lastResult remains unchanged
-				continue
-			}
-			
-			var codeOutput CodeExecutionOutput
-			err = workflow.ExecuteActivity(activityCtx, (*Activities).CodeExecutionActivity, CodeExecutionInput{
-				Code:  codeData.Code,
-				Input: lastResult,
-			}).Get(activityCtx, &codeOutput)
-			
-			if err != nil {
-				errMsg := fmt.Sprintf("code execution activity failed: %v", err)
-				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, errMsg).Get(activityCtx, nil)
-				return nil, err
-			}
-			
-			if codeOutput.Error != "" {
-				errMsg := fmt.Sprintf("code execution error: %s", codeOutput.Error)
-				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, errMsg).Get(activityCtx, nil)
-				return nil, temporal.NewApplicationError("code execution failed", "CodeExecutionError", codeOutput.Error)
+		nodeInput := lastResult
+		if version != workflow.DefaultVersion {
+			nodeInput = resolveNodeInput(node.ID, dagStruct.Edges, results, position, lastResult)
+		}
+		var output interface{}
+		if node.Type == "batch" {
+			position[node.ID] = i
+			output, err = executeBatch(activityCtx, node, &dagStruct, order, input, nodeInput, results, position)
+		} else {
+			output, err = executeNode(activityCtx, node, &dagStruct, input, nodeInput)
+		}
+		if err != nil {
+			var failure *nodeFailure
+			if errors.As(err, &failure) {
+				_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, failure.message).Get(activityCtx, nil)
+				return nil, failure.cause
 			}
-			
-			lastResult = codeOutput.Result
-		case "output":
-			// No-op, but we keep lastResult
-		default:
-			// unknown node type
+			_ = workflow.ExecuteActivity(activityCtx, (*Activities).StoreExecutionErrorActivity, input.ExecutionID, err.Error()).Get(activityCtx, nil)
+			return nil, err
 		}
+
+		result := toNodeResult(output)
+		results[node.ID] = result
+		position[node.ID] = i
+		lastResult = result.Default
 	}
 
 	// Store result
@@ -155,6 +130,484 @@ func DAGWorkflow(ctx workflow.Context, input WorkflowInput) (*WorkflowResult, er
 	return result, nil
 }
 
+// defaultActivityOptions apply to node activities unless their case in
+// executeNode overrides them
+func defaultActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+}
+
+// nodeFailure pairs the message stored on the execution record with the
+// error returned to Temporal
+type nodeFailure struct {
+	message string
+	cause   error
+}
+
+func (f *nodeFailure) Error() string {
+	return f.message
+}
+
+func (f *nodeFailure) Unwrap() error {
+	return f.cause
+}
+
+func failNode(cause error, format string, args ...interface{}) error {
+	return &nodeFailure{message: fmt.Sprintf(format, args...), cause: cause}
+}
+
+// nodeResult holds a node's output. Nodes with several outputs (e.g. filter)
+// expose them by handle; edges without a matching sourceHandle get Default.
+type nodeResult struct {
+	Default interface{}
+	Handles map[string]interface{}
+}
+
+func toNodeResult(output interface{}) nodeResult {
+	if r, ok := output.(nodeResult); ok {
+		return r
+	}
+	return nodeResult{Default: output}
+}
+
+// resolveNodeInput picks the output feeding nodeID. With several incoming
+// edges the most recently executed source wins; nodes without incoming edges
+// receive the previous node's output. An edge from an output its source did
+// not emit carries nil.
+func resolveNodeInput(nodeID string, edges []models.Edge, results map[string]nodeResult, position map[string]int, lastResult interface{}) interface{} {
+	var chosen *models.Edge
+	for i := range edges {
+		edge := &edges[i]
+		if edge.Target != nodeID {
+			continue
+		}
+		if _, ok := results[edge.Source]; !ok {
+			continue
+		}
+		if chosen == nil || position[edge.Source] > position[chosen.Source] {
+			chosen = edge
+		}
+	}
+	if chosen == nil {
+		return lastResult
+	}
+
+	result := results[chosen.Source]
+	if chosen.SourceHandle != "" {
+		return result.Handles[chosen.SourceHandle]
+	}
+	return result.Default
+}
+
+// executeNode runs a single node against its input and returns the node's
+// own output
+func executeNode(ctx workflow.Context, node *models.Node, dagStruct *models.DAGStructure, input WorkflowInput, nodeInput interface{}) (interface{}, error) {
+	switch node.Type {
+	case "start":
+		startResult := map[string]interface{}{"start": node.ID}
+		if input.Payload != nil {
+			startResult["payload"] = input.Payload
+		}
+		return startResult, nil
+	case "http":
+		httpData, err := parseHTTPData(node.Data)
+		if err != nil {
+			return nil, failNode(err, "failed to parse HTTP node data: %v", err)
+		}
+		var httpResp HttpRequestOutput
+		err = workflow.ExecuteActivity(ctx, (*Activities).HttpRequestActivity, HttpRequestInput{
+			Method:  httpData.Method,
+			URL:     httpData.URL,
+			Headers: httpData.Headers,
+			Query:   httpData.Query,
+			Body:    httpData.Body,
+
+			ResponseFormat: httpData.ResponseFormat,
+		}).Get(ctx, &httpResp)
+		if err != nil {
+			return nil, failNode(err, "HTTP request failed: %v", err)
+		}
+		return httpResp, nil
+	case "code":
+		codeData, err := parseCodeData(node.Data)
+		if err != nil {
+			return nil, failNode(err, "failed to parse code node data: %v", err)
+		}
+
+		// If code is empty, passthrough
+		if codeData.Code == "" || strings.TrimSpace(codeData.Code) == "" {
+			return nodeInput, nil
+		}
+
+		var codeOutput CodeExecutionOutput
+		err = workflow.ExecuteActivity(ctx, (*Activities).CodeExecutionActivity, CodeExecutionInput{
+			Code:  codeData.Code,
+			Input: nodeInput,
+		}).Get(ctx, &codeOutput)
+		if err != nil {
+			return nil, failNode(err, "code execution activity failed: %v", err)
+		}
+
+		if codeOutput.Error != "" {
+			return nil, failNode(
+				temporal.NewApplicationError("code execution failed", "CodeExecutionError", codeOutput.Error),
+				"code execution error: %s", codeOutput.Error)
+		}
+
+		return codeOutput.Result, nil
+	case "set":
+		var setData models.SetNodeData
+		if err := dag.DecodeNodeData(node.Data, &setData); err != nil {
+			return nil, failNode(err, "failed to parse set node data: %v", err)
+		}
+		var result interface{}
+		err := workflow.ExecuteActivity(ctx, (*Activities).SetFieldsActivity, SetFieldsInput{
+			Assignments: setData.Assignments,
+			KeepOnlySet: setData.KeepOnlySet,
+			Input:       nodeInput,
+		}).Get(ctx, &result)
+		if err != nil {
+			return nil, failNode(err, "set node '%s' failed: %v", node.ID, err)
+		}
+		return result, nil
+	case "filter":
+		var filterData models.FilterNodeData
+		if err := dag.DecodeNodeData(node.Data, &filterData); err != nil {
+			return nil, failNode(err, "failed to parse filter node data: %v", err)
+		}
+		var filterOutput FilterOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).FilterActivity, FilterInput{
+			Filter: filterData,
+			Input:  nodeInput,
+		}).Get(ctx, &filterOutput)
+		if err != nil {
+			return nil, failNode(err, "filter node '%s' failed: %v", node.ID, err)
+		}
+		handles := map[string]interface{}{"kept": filterOutput.Kept}
+		if filterData.EmitDiscarded {
+			handles["discarded"] = filterOutput.Discarded
+		}
+		return nodeResult{Default: filterOutput.Kept, Handles: handles}, nil
+	case "items":
+		var itemsData models.ItemsNodeData
+		if err := dag.DecodeNodeData(node.Data, &itemsData); err != nil {
+			return nil, failNode(err, "failed to parse items node data: %v", err)
+		}
+		var items []interface{}
+		err := workflow.ExecuteActivity(ctx, (*Activities).ItemsActivity, ItemsInput{
+			Path:       itemsData.Path,
+			Operations: itemsData.Operations,
+			Input:      nodeInput,
+		}).Get(ctx, &items)
+		if err != nil {
+			return nil, failNode(err, "items node '%s' failed: %v", node.ID, err)
+		}
+		return items, nil
+	case "aggregate":
+		var aggData models.AggregateNodeData
+		if err := dag.DecodeNodeData(node.Data, &aggData); err != nil {
+			return nil, failNode(err, "failed to parse aggregate node data: %v", err)
+		}
+		var groups []map[string]interface{}
+		err := workflow.ExecuteActivity(ctx, (*Activities).AggregateActivity, AggregateInput{
+			Aggregate: aggData,
+			Input:     nodeInput,
+		}).Get(ctx, &groups)
+		if err != nil {
+			return nil, failNode(err, "aggregate node '%s' failed: %v", node.ID, err)
+		}
+		return groups, nil
+	case "postgres":
+		var pgData models.PostgresNodeData
+		if err := dag.DecodeNodeData(node.Data, &pgData); err != nil {
+			return nil, failNode(err, "failed to parse postgres node data: %v", err)
+		}
+		pgCtx := ctx
+		if pgData.Mode != models.PostgresModeTransaction && !postgresReadOnly(pgData.Query) {
+			// A write whose commit was not acknowledged may already be applied;
+			// transactions roll back instead
+			ao := workflow.GetActivityOptions(ctx)
+			ao.RetryPolicy = &temporal.RetryPolicy{MaximumAttempts: 1}
+			pgCtx = workflow.WithActivityOptions(ctx, ao)
+		}
+		var rows []map[string]interface{}
+		err := workflow.ExecuteActivity(pgCtx, (*Activities).PostgresActivity, PostgresInput{
+			Postgres: pgData,
+			Input:    nodeInput,
+		}).Get(ctx, &rows)
+		if err != nil {
+			return nil, failNode(err, "postgres node '%s' failed: %v", node.ID, err)
+		}
+		return rows, nil
+	case "redis":
+		var redisData models.RedisNodeData
+		if err := dag.DecodeNodeData(node.Data, &redisData); err != nil {
+			return nil, failNode(err, "failed to parse redis node data: %v", err)
+		}
+		redisCtx := ctx
+		if !redisReadOnly(redisData.Operation) {
+			// A retried incr or lpush would apply twice
+			ao := workflow.GetActivityOptions(ctx)
+			ao.RetryPolicy = &temporal.RetryPolicy{MaximumAttempts: 1}
+			redisCtx = workflow.WithActivityOptions(ctx, ao)
+		}
+		var redisOutput RedisOutput
+		err := workflow.ExecuteActivity(redisCtx, (*Activities).RedisActivity, RedisInput{
+			Redis: redisData,
+			Input: nodeInput,
+		}).Get(ctx, &redisOutput)
+		if err != nil {
+			return nil, failNode(err, "redis node '%s' failed: %v", node.ID, err)
+		}
+		return redisOutput, nil
+	case "email":
+		var emailData models.EmailNodeData
+		if err := dag.DecodeNodeData(node.Data, &emailData); err != nil {
+			return nil, failNode(err, "failed to parse email node data: %v", err)
+		}
+		var emailOutput SendEmailOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).SendEmailActivity, SendEmailInput{
+			Email: emailData,
+			Input: nodeInput,
+		}).Get(ctx, &emailOutput)
+		if err != nil {
+			return nil, failNode(err, "email node '%s' failed: %v", node.ID, err)
+		}
+		return emailOutput, nil
+	case "csv":
+		var csvData models.CSVNodeData
+		if err := dag.DecodeNodeData(node.Data, &csvData); err != nil {
+			return nil, failNode(err, "failed to parse csv node data: %v", err)
+		}
+		var result interface{}
+		err := workflow.ExecuteActivity(ctx, (*Activities).CSVActivity, CSVInput{
+			CSV:   csvData,
+			Input: nodeInput,
+		}).Get(ctx, &result)
+		if err != nil {
+			return nil, failNode(err, "csv node '%s' failed: %v", node.ID, err)
+		}
+		return result, nil
+	case "parse":
+		var parseData models.ParseNodeData
+		if err := dag.DecodeNodeData(node.Data, &parseData); err != nil {
+			return nil, failNode(err, "failed to parse parse node data: %v", err)
+		}
+		var result interface{}
+		err := workflow.ExecuteActivity(ctx, (*Activities).ParseActivity, ParseInput{
+			Parse: parseData,
+			Input: nodeInput,
+		}).Get(ctx, &result)
+		if err != nil {
+			return nil, failNode(err, "parse node '%s' failed: %v", node.ID, err)
+		}
+		return result, nil
+	case "template":
+		var tmplData models.TemplateNodeData
+		if err := dag.DecodeNodeData(node.Data, &tmplData); err != nil {
+			return nil, failNode(err, "failed to parse template node data: %v", err)
+		}
+		var tmplOutput TemplateOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).TemplateActivity, TemplateInput{
+			Template: tmplData,
+			Input:    nodeInput,
+		}).Get(ctx, &tmplOutput)
+		if err != nil {
+			return nil, failNode(err, "template node '%s' failed: %v", node.ID, err)
+		}
+		return tmplOutput, nil
+	case "datetime":
+		var dtData models.DatetimeNodeData
+		if err := dag.DecodeNodeData(node.Data, &dtData); err != nil {
+			return nil, failNode(err, "failed to parse datetime node data: %v", err)
+		}
+		var dtOutput DatetimeOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).DatetimeActivity, DatetimeInput{
+			Datetime: dtData,
+			Timezone: dagStruct.Timezone,
+			Input:    nodeInput,
+		}).Get(ctx, &dtOutput)
+		if err != nil {
+			return nil, failNode(err, "datetime node '%s' failed: %v", node.ID, err)
+		}
+		return dtOutput, nil
+	case "crypto":
+		var cryptoData models.CryptoNodeData
+		if err := dag.DecodeNodeData(node.Data, &cryptoData); err != nil {
+			return nil, failNode(err, "failed to parse crypto node data: %v", err)
+		}
+		var cryptoOutput CryptoOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).CryptoActivity, CryptoInput{
+			Crypto: cryptoData,
+			Input:  nodeInput,
+		}).Get(ctx, &cryptoOutput)
+		if err != nil {
+			return nil, failNode(err, "crypto node '%s' failed: %v", node.ID, err)
+		}
+		return cryptoOutput, nil
+	case "jwt":
+		var jwtData models.JWTNodeData
+		if err := dag.DecodeNodeData(node.Data, &jwtData); err != nil {
+			return nil, failNode(err, "failed to parse jwt node data: %v", err)
+		}
+		var jwtOutput JWTOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).JWTActivity, JWTInput{
+			JWT:   jwtData,
+			Input: nodeInput,
+		}).Get(ctx, &jwtOutput)
+		if err != nil {
+			return nil, failNode(err, "jwt node '%s' failed: %v", node.ID, err)
+		}
+		return jwtOutput, nil
+	case "graphql":
+		var gqlData models.GraphQLNodeData
+		if err := dag.DecodeNodeData(node.Data, &gqlData); err != nil {
+			return nil, failNode(err, "failed to parse graphql node data: %v", err)
+		}
+		var gqlOutput GraphQLOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).GraphQLActivity, GraphQLInput{
+			GraphQL: gqlData,
+			Input:   nodeInput,
+		}).Get(ctx, &gqlOutput)
+		if err != nil {
+			return nil, failNode(err, "graphql node '%s' failed: %v", node.ID, err)
+		}
+		// Downstream nodes get data; partial errors are on the "errors" output
+		return nodeResult{
+			Default: gqlOutput.Data,
+			Handles: map[string]interface{}{"errors": gqlOutput.Errors},
+		}, nil
+	case "grpc":
+		var grpcData models.GrpcNodeData
+		if err := dag.DecodeNodeData(node.Data, &grpcData); err != nil {
+			return nil, failNode(err, "failed to parse grpc node data: %v", err)
+		}
+		// A call deadline longer than the default activity timeout would be
+		// cut short and retried, so each attempt gets the deadline plus time
+		// to connect and fetch the schema
+		grpcCtx := ctx
+		if d, err := time.ParseDuration(grpcData.Timeout); err == nil && d > 0 {
+			ao := workflow.GetActivityOptions(ctx)
+			ao.StartToCloseTimeout = d + 15*time.Second
+			grpcCtx = workflow.WithActivityOptions(ctx, ao)
+		}
+		var grpcOutput GrpcOutput
+		err := workflow.ExecuteActivity(grpcCtx, (*Activities).GrpcActivity, GrpcInput{
+			Grpc:  grpcData,
+			Input: nodeInput,
+		}).Get(ctx, &grpcOutput)
+		if err != nil {
+			return nil, failNode(err, "grpc node '%s' failed: %v", node.ID, err)
+		}
+		return grpcOutput, nil
+	case "s3":
+		var s3Data models.S3NodeData
+		if err := dag.DecodeNodeData(node.Data, &s3Data); err != nil {
+			return nil, failNode(err, "failed to parse s3 node data: %v", err)
+		}
+		var s3Output S3Output
+		err := workflow.ExecuteActivity(ctx, (*Activities).S3Activity, S3Input{
+			S3:    s3Data,
+			Input: nodeInput,
+		}).Get(ctx, &s3Output)
+		if err != nil {
+			return nil, failNode(err, "s3 node '%s' failed: %v", node.ID, err)
+		}
+		return s3Output, nil
+	case "file":
+		var fileData models.FileNodeData
+		if err := dag.DecodeNodeData(node.Data, &fileData); err != nil {
+			return nil, failNode(err, "failed to parse file node data: %v", err)
+		}
+		fileCtx := ctx
+		if fileData.Operation == models.FileOpAppend {
+			// An attempt that timed out may already have appended, and a
+			// retry would append the value a second time
+			ao := workflow.GetActivityOptions(ctx)
+			ao.RetryPolicy = &temporal.RetryPolicy{MaximumAttempts: 1}
+			fileCtx = workflow.WithActivityOptions(ctx, ao)
+		}
+		var fileOutput FileOutput
+		err := workflow.ExecuteActivity(fileCtx, (*Activities).FileActivity, FileInput{
+			File:  fileData,
+			Input: nodeInput,
+		}).Get(ctx, &fileOutput)
+		if err != nil {
+			return nil, failNode(err, "file node '%s' failed: %v", node.ID, err)
+		}
+		return fileOutput, nil
+	case "compress":
+		var compressData models.CompressNodeData
+		if err := dag.DecodeNodeData(node.Data, &compressData); err != nil {
+			return nil, failNode(err, "failed to parse compress node data: %v", err)
+		}
+		var compressOutput CompressOutput
+		err := workflow.ExecuteActivity(ctx, (*Activities).CompressActivity, CompressInput{
+			Compress: compressData,
+			Input:    nodeInput,
+		}).Get(ctx, &compressOutput)
+		if err != nil {
+			return nil, failNode(err, "compress node '%s' failed: %v", node.ID, err)
+		}
+		return compressOutput, nil
+	case "nats":
+		var natsData models.NATSNodeData
+		if err := dag.DecodeNodeData(node.Data, &natsData); err != nil {
+			return nil, failNode(err, "failed to parse nats node data: %v", err)
+		}
+		// Each attempt waits up to the request timeout for a reply, plus time
+		// to connect
+		natsCtx := ctx
+		if d, err := time.ParseDuration(natsData.Timeout); err == nil && d > 0 {
+			ao := workflow.GetActivityOptions(ctx)
+			ao.StartToCloseTimeout = d + 15*time.Second
+			natsCtx = workflow.WithActivityOptions(ctx, ao)
+		}
+		var natsOutput NATSOutput
+		err := workflow.ExecuteActivity(natsCtx, (*Activities).NATSActivity, NATSInput{
+			NATS:  natsData,
+			Input: nodeInput,
+		}).Get(ctx, &natsOutput)
+		if err != nil {
+			return nil, failNode(err, "nats node '%s' failed: %v", node.ID, err)
+		}
+		return natsOutput, nil
+	case "exec":
+		var execData models.ExecNodeData
+		if err := dag.DecodeNodeData(node.Data, &execData); err != nil {
+			return nil, failNode(err, "failed to parse exec node data: %v", err)
+		}
+		timeout := models.DefaultExecTimeout
+		if d, err := time.ParseDuration(execData.Timeout); err == nil && d > 0 {
+			timeout = d
+		}
+		// Commands may have side effects, so they run once, and may outlast
+		// the default activity timeout
+		ao := workflow.GetActivityOptions(ctx)
+		ao.StartToCloseTimeout = timeout + 15*time.Second
+		ao.RetryPolicy = &temporal.RetryPolicy{MaximumAttempts: 1}
+		var execOutput ExecOutput
+		err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, ao), (*Activities).ExecActivity, ExecInput{
+			Exec:  execData,
+			Input: nodeInput,
+		}).Get(ctx, &execOutput)
+		if err != nil {
+			return nil, failNode(err, "exec node '%s' failed: %v", node.ID, err)
+		}
+		return execOutput, nil
+	case "output":
+		// No-op, passes its input through
+		return nodeInput, nil
+	default:
+		// unknown node type
+		return nodeInput, nil
+	}
+}
+
 func parseHTTPData(data interface{}) (*models.HttpNodeData, error) {
 	switch v := data.(type) {
 	case models.HttpNodeData:
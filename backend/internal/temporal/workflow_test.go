@@ -0,0 +1,108 @@
+package temporal
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestResolveNodeInputHandles(t *testing.T) {
+	results := map[string]nodeResult{
+		"filter": {Default: []interface{}{"kept"}, Handles: map[string]interface{}{"kept": []interface{}{"kept"}}},
+	}
+	position := map[string]int{"filter": 1}
+
+	tests := []struct {
+		handle string
+		want   interface{}
+	}{
+		{handle: "", want: []interface{}{"kept"}},
+		{handle: "kept", want: []interface{}{"kept"}},
+		{handle: "discarded", want: nil}, // not emitted
+	}
+	for _, tt := range tests {
+		edges := []models.Edge{{ID: "e", Source: "filter", Target: "next", SourceHandle: tt.handle}}
+		got := resolveNodeInput("next", edges, results, position, "previous")
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("handle %q: got %v, want %v", tt.handle, got, tt.want)
+		}
+	}
+}
+
+// edgeInputDAG has a node whose incoming edge is not from the node before it
+// in execution order, and an edge from an output its source does not emit
+func edgeInputDAG() models.DAGStructure {
+	return models.DAGStructure{
+		Nodes: []models.Node{
+			{ID: "start", Type: "start"},
+			{ID: "a", Type: "set", Data: map[string]interface{}{"assignments": []interface{}{map[string]interface{}{"name": "from", "value": "a"}}}},
+			{ID: "b", Type: "code", Data: map[string]interface{}{"code": ""}},
+			{ID: "out", Type: "output"},
+		},
+		Edges: []models.Edge{
+			{ID: "e1", Source: "start", Target: "a"},
+			{ID: "e2", Source: "start", Target: "b"},
+			{ID: "e3", Source: "b", Target: "out"},
+			{ID: "e4", Source: "a", Target: "out", SourceHandle: "extra"},
+		},
+	}
+}
+
+func TestDAGWorkflowEdgeInputIsVersioned(t *testing.T) {
+	setStub := func(context.Context, SetFieldsInput) (map[string]interface{}, error) {
+		return map[string]interface{}{"from": "a"}, nil
+	}
+
+	// Runs started before the change replay with the previous node's output
+	// as input and accept the unknown handle
+	env, _ := newWorkflowTestEnv(t, edgeInputDAG())
+	env.RegisterActivityWithOptions(setStub, activity.RegisterOptions{Name: "SetFieldsActivity"})
+	env.OnGetVersion(edgeInputChange, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("old version: %v", err)
+	}
+	var result WorkflowResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"from": "a"}; !reflect.DeepEqual(result.Result, want) {
+		t.Errorf("old version result = %v, want the previous node's output %v", result.Result, want)
+	}
+
+	// New runs reject the unknown handle
+	env, stored := newWorkflowTestEnv(t, edgeInputDAG())
+	env.RegisterActivityWithOptions(setStub, activity.RegisterOptions{Name: "SetFieldsActivity"})
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+	if env.GetWorkflowError() == nil || len(*stored) != 1 || !strings.Contains((*stored)[0], "unknown output 'extra'") {
+		t.Errorf("new version: stored errors %q, want the unknown handle rejected", *stored)
+	}
+}
+
+func TestDAGWorkflowFeedsEdgeInput(t *testing.T) {
+	dagStruct := edgeInputDAG()
+	dagStruct.Edges[3].SourceHandle = ""
+	env, _ := newWorkflowTestEnv(t, dagStruct)
+	env.RegisterActivityWithOptions(func(context.Context, SetFieldsInput) (map[string]interface{}, error) {
+		return map[string]interface{}{"from": "a"}, nil
+	}, activity.RegisterOptions{Name: "SetFieldsActivity"})
+	env.ExecuteWorkflow(DAGWorkflow, WorkflowInput{WorkflowID: "wf", ExecutionID: "exec"})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+	var result WorkflowResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	// b runs after a but is fed by start, and out takes b, the source that
+	// ran last
+	if want := map[string]interface{}{"start": "start"}; !reflect.DeepEqual(result.Result, want) {
+		t.Errorf("result = %v, want start's output %v", result.Result, want)
+	}
+}
@@ -0,0 +1,317 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-smtp"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+)
+
+// EmailSource runs an embedded SMTP server and starts an execution for every
+// accepted recipient that is mapped to a workflow
+type EmailSource struct {
+	server  *smtp.Server
+	store   *storage.BinaryStore
+	starter Starter
+
+	mu     sync.RWMutex
+	routes map[string][]string // lower-cased address -> workflow IDs
+}
+
+// NewEmailSource creates the SMTP listener; call ListenAndServe to accept mail
+func NewEmailSource(addr, domain string, maxMessageBytes int64, store *storage.BinaryStore, starter Starter) *EmailSource {
+	s := &EmailSource{
+		store:   store,
+		starter: starter,
+		routes:  make(map[string][]string),
+	}
+
+	server := smtp.NewServer(smtp.BackendFunc(func(c *smtp.Conn) (smtp.Session, error) {
+		return &emailSession{source: s}, nil
+	}))
+	server.Addr = addr
+	server.Domain = domain
+	server.MaxMessageBytes = maxMessageBytes
+	server.MaxRecipients = 50
+	server.ReadTimeout = time.Minute
+	server.WriteTimeout = time.Minute
+	s.server = server
+	return s
+}
+
+func (s *EmailSource) Type() string {
+	return models.TriggerTypeEmail
+}
+
+// ListenAndServe blocks serving SMTP until Close is called
+func (s *EmailSource) ListenAndServe() error {
+	log.Printf("[Trigger] SMTP server listening on %s", s.server.Addr)
+	return s.server.ListenAndServe()
+}
+
+func (s *EmailSource) Sync(ctx context.Context, bindings []Binding) error {
+	routes := make(map[string][]string)
+	for _, b := range bindings {
+		addr := normalizeAddress(b.Config.Address)
+		if addr == "" {
+			continue
+		}
+		routes[addr] = append(routes[addr], b.WorkflowID)
+	}
+
+	s.mu.Lock()
+	s.routes = routes
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *EmailSource) Close() error {
+	return s.server.Close()
+}
+
+func (s *EmailSource) workflowsFor(addr string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.routes[normalizeAddress(addr)]...)
+}
+
+// deliver starts an execution for every recipient/workflow pair. Once one has
+// started the message is accepted even if others fail, because a retry by the
+// sending MTA would start the successful ones again; failures are only logged.
+func (s *EmailSource) deliver(from string, recipients []string, raw []byte) error {
+	ctx := context.Background()
+
+	msg, err := parseEmail(ctx, s.store, raw)
+	if err != nil {
+		return &smtp.SMTPError{Code: 554, EnhancedCode: smtp.EnhancedCode{5, 6, 0}, Message: "Malformed message"}
+	}
+	msg["envelope_from"] = from
+
+	started, failed := 0, 0
+	for _, rcpt := range recipients {
+		for _, workflowID := range s.workflowsFor(rcpt) {
+			payload := make(map[string]interface{}, len(msg)+1)
+			for k, v := range msg {
+				payload[k] = v
+			}
+			payload["recipient"] = rcpt
+
+			execID, err := s.starter.StartExecutionWithPayload(ctx, workflowID, payload)
+			if err != nil {
+				failed++
+				log.Printf("[Trigger] failed to start workflow %s for mail to %s: %v", workflowID, rcpt, err)
+				continue
+			}
+			started++
+			log.Printf("[Trigger] started execution %s of workflow %s for mail to %s", execID, workflowID, rcpt)
+		}
+	}
+
+	// Nothing started yet, so it is safe to let the sender try again
+	if started == 0 && failed > 0 {
+		return &smtp.SMTPError{Code: 451, EnhancedCode: smtp.EnhancedCode{4, 3, 0}, Message: "Temporary failure, try again later"}
+	}
+	if failed > 0 {
+		log.Printf("[Trigger] accepted mail %v with %d of %d executions not started", msg["message_id"], failed, started+failed)
+	}
+	return nil
+}
+
+type emailSession struct {
+	source     *EmailSource
+	from       string
+	recipients []string
+}
+
+func (e *emailSession) Reset() {
+	e.from = ""
+	e.recipients = nil
+}
+
+func (e *emailSession) Logout() error {
+	return nil
+}
+
+func (e *emailSession) Mail(from string, opts *smtp.MailOptions) error {
+	e.from = from
+	return nil
+}
+
+func (e *emailSession) Rcpt(to string, opts *smtp.RcptOptions) error {
+	if len(e.source.workflowsFor(to)) == 0 {
+		return &smtp.SMTPError{Code: 550, EnhancedCode: smtp.EnhancedCode{5, 1, 1}, Message: "No workflow for this address"}
+	}
+	e.recipients = append(e.recipients, to)
+	return nil
+}
+
+func (e *emailSession) Data(r io.Reader) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return e.source.deliver(e.from, e.recipients, raw)
+}
+
+func normalizeAddress(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if parsed, err := mail.ParseAddress(addr); err == nil {
+		addr = parsed.Address
+	}
+	return strings.ToLower(addr)
+}
+
+// parseEmail converts a raw RFC 5322 message into the execution payload.
+// Attachments are stored as binary data and referenced.
+func parseEmail(ctx context.Context, store *storage.BinaryStore, raw []byte) (map[string]interface{}, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := new(mime.WordDecoder)
+	decode := func(v string) string {
+		if decoded, err := decoder.DecodeHeader(v); err == nil {
+			return decoded
+		}
+		return v
+	}
+
+	headers := make(map[string]interface{}, len(msg.Header))
+	for key, values := range msg.Header {
+		decoded := make([]string, len(values))
+		for i, v := range values {
+			decoded[i] = decode(v)
+		}
+		if len(decoded) == 1 {
+			headers[key] = decoded[0]
+		} else {
+			headers[key] = decoded
+		}
+	}
+
+	payload := map[string]interface{}{
+		"headers":    headers,
+		"from":       addressList(msg.Header, "From"),
+		"to":         addressList(msg.Header, "To"),
+		"cc":         addressList(msg.Header, "Cc"),
+		"subject":    decode(msg.Header.Get("Subject")),
+		"message_id": msg.Header.Get("Message-Id"),
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		payload["date"] = date.UTC()
+	}
+
+	parts := &emailParts{attachments: []*models.BinaryRef{}}
+	if err := parts.walk(ctx, store, msg.Header, msg.Body, decoder); err != nil {
+		return nil, err
+	}
+	payload["text"] = parts.text
+	payload["html"] = parts.html
+	payload["attachments"] = parts.attachments
+	return payload, nil
+}
+
+func addressList(h mail.Header, key string) []string {
+	list, err := h.AddressList(key)
+	if err != nil {
+		return []string{}
+	}
+	out := make([]string, len(list))
+	for i, a := range list {
+		out[i] = a.Address
+	}
+	return out
+}
+
+// partHeader is satisfied by both mail.Header and textproto.MIMEHeader
+type partHeader interface {
+	Get(key string) string
+}
+
+type emailParts struct {
+	text        string
+	html        string
+	attachments []*models.BinaryRef
+}
+
+func (p *emailParts) walk(ctx context.Context, store *storage.BinaryStore, header partHeader, body io.Reader, decoder *mime.WordDecoder) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := p.walk(ctx, store, part.Header, part, decoder); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(transferDecoder(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	fileName := dispParams["filename"]
+	if fileName == "" {
+		fileName = params["name"]
+	}
+	if decoded, err := decoder.DecodeHeader(fileName); err == nil {
+		fileName = decoded
+	}
+
+	isAttachment := disposition == "attachment" || fileName != ""
+	switch {
+	case !isAttachment && mediaType == "text/plain" && p.text == "":
+		p.text = string(content)
+	case !isAttachment && mediaType == "text/html" && p.html == "":
+		p.html = string(content)
+	default:
+		if fileName == "" {
+			fileName = fmt.Sprintf("attachment-%d", len(p.attachments)+1)
+		}
+		ref, err := store.Put(ctx, fileName, mediaType, content)
+		if err != nil {
+			return err
+		}
+		p.attachments = append(p.attachments, ref)
+	}
+	return nil
+}
+
+func transferDecoder(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		// The decoder skips the CR/LF line wrapping used in mail bodies
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
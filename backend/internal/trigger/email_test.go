@@ -0,0 +1,112 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// partialStarter fails to start the workflows in fail and records the rest
+type partialStarter struct {
+	recordingStarter
+	fail map[string]bool
+}
+
+func (s *partialStarter) StartExecutionWithPayload(ctx context.Context, workflowID string, payload interface{}) (string, error) {
+	if s.fail[workflowID] {
+		return "", errors.New("workflow is inactive")
+	}
+	return s.recordingStarter.StartExecutionWithPayload(ctx, workflowID, payload)
+}
+
+// newTestEmailSource serves SMTP on a loopback port and returns its address
+func newTestEmailSource(t *testing.T, starter Starter, bindings []Binding) (*EmailSource, string) {
+	t.Helper()
+	src := NewEmailSource("", "localhost", 1<<20, nil, starter)
+	if err := src.Sync(context.Background(), bindings); err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go src.server.Serve(ln)
+	t.Cleanup(func() { src.Close() })
+	return src, ln.Addr().String()
+}
+
+func emailBindingFor(workflowID, address string) Binding {
+	return Binding{WorkflowID: workflowID, NodeID: "start", Config: models.TriggerConfig{Address: address}}
+}
+
+const testMail = "From: Alice <alice@example.com>\r\n" +
+	"To: support@workflows.local\r\n" +
+	"Subject: Order 42\r\n" +
+	"Message-ID: <42@example.com>\r\n" +
+	"\r\n" +
+	"Where is my order?\r\n"
+
+func TestEmailSourceStartsExecutions(t *testing.T) {
+	starter := &recordingStarter{}
+	_, addr := newTestEmailSource(t, starter, []Binding{
+		emailBindingFor("wf-support", "support@workflows.local"),
+		emailBindingFor("wf-audit", "Support@Workflows.local"),
+	})
+
+	err := smtp.SendMail(addr, nil, "alice@example.com", []string{"support@workflows.local"}, []byte(testMail))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := starter.count("wf-support"), starter.count("wf-audit"); a != 1 || b != 1 {
+		t.Fatalf("started wf-support %d and wf-audit %d times, want once each", a, b)
+	}
+	payload := starter.payloads["wf-support"][0].(map[string]interface{})
+	if payload["subject"] != "Order 42" || payload["recipient"] != "support@workflows.local" || payload["envelope_from"] != "alice@example.com" {
+		t.Errorf("payload = %v", payload)
+	}
+	if text, _ := payload["text"].(string); !strings.Contains(text, "Where is my order?") {
+		t.Errorf("text = %q, want the body", payload["text"])
+	}
+}
+
+func TestEmailSourceRejectsUnknownRecipient(t *testing.T) {
+	starter := &recordingStarter{}
+	_, addr := newTestEmailSource(t, starter, []Binding{emailBindingFor("wf", "support@workflows.local")})
+
+	err := smtp.SendMail(addr, nil, "alice@example.com", []string{"sales@workflows.local"}, []byte(testMail))
+	if err == nil || !strings.HasPrefix(err.Error(), "550") {
+		t.Errorf("got %v, want a 550 rejection", err)
+	}
+}
+
+func TestEmailSourceAcceptsPartialFailure(t *testing.T) {
+	// Once wf-ok has started, a 451 would make the sender retry and start it
+	// again, so the message is accepted and the failure only logged
+	starter := &partialStarter{fail: map[string]bool{"wf-down": true}}
+	_, addr := newTestEmailSource(t, starter, []Binding{
+		emailBindingFor("wf-ok", "support@workflows.local"),
+		emailBindingFor("wf-down", "support@workflows.local"),
+	})
+
+	if err := smtp.SendMail(addr, nil, "alice@example.com", []string{"support@workflows.local"}, []byte(testMail)); err != nil {
+		t.Fatalf("got %v, want the message accepted", err)
+	}
+	if n := starter.count("wf-ok"); n != 1 {
+		t.Errorf("started wf-ok %d times, want once", n)
+	}
+}
+
+func TestEmailSourceTemporaryFailureWhenNothingStarted(t *testing.T) {
+	starter := &partialStarter{fail: map[string]bool{"wf-down": true}}
+	_, addr := newTestEmailSource(t, starter, []Binding{emailBindingFor("wf-down", "support@workflows.local")})
+
+	err := smtp.SendMail(addr, nil, "alice@example.com", []string{"support@workflows.local"}, []byte(testMail))
+	if err == nil || !strings.HasPrefix(err.Error(), "451") {
+		t.Errorf("got %v, want a 451 so the sender retries", err)
+	}
+}
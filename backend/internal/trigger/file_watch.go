@@ -0,0 +1,301 @@
+package trigger
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/internal/storage"
+	"github.com/your-org/n8n-clone/pkg/fsutil"
+)
+
+// Files up to this size that are valid UTF-8 are inlined as "content";
+// anything else is stored as binary data and passed by reference
+const maxInlineFileSize = 1 << 20
+
+const defaultFileWatchDebounce = 2 * time.Second
+
+// FileWatchSource polls directories under a sandbox root and starts an
+// execution for each new or modified file. Polling is used instead of inotify
+// because the watched directories are typically network mounts.
+type FileWatchSource struct {
+	root         string
+	pollInterval time.Duration
+	db           *sql.DB
+	store        *storage.BinaryStore
+	starter      Starter
+
+	mu       sync.Mutex
+	bindings []Binding
+	pending  map[string]pendingFile // binding key + path -> last observed state
+	done     chan struct{}
+}
+
+type pendingFile struct {
+	size      int64
+	modTime   time.Time
+	firstSeen time.Time
+}
+
+type ledgerEntry struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewFileWatchSource starts polling; root bounds every configured directory
+func NewFileWatchSource(root string, pollInterval time.Duration, db *sql.DB, store *storage.BinaryStore, starter Starter) *FileWatchSource {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	s := &FileWatchSource{
+		root:         root,
+		pollInterval: pollInterval,
+		db:           db,
+		store:        store,
+		starter:      starter,
+		pending:      make(map[string]pendingFile),
+		done:         make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+func (s *FileWatchSource) Type() string {
+	return models.TriggerTypeFileWatch
+}
+
+func (s *FileWatchSource) Sync(ctx context.Context, bindings []Binding) error {
+	s.mu.Lock()
+	s.bindings = bindings
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *FileWatchSource) Close() error {
+	close(s.done)
+	return nil
+}
+
+func (s *FileWatchSource) loop() {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			bindings := append([]Binding(nil), s.bindings...)
+			s.mu.Unlock()
+
+			for _, b := range bindings {
+				if err := s.scan(context.Background(), b); err != nil {
+					log.Printf("[Trigger] file_watch scan for workflow %s failed: %v", b.WorkflowID, err)
+				}
+			}
+		}
+	}
+}
+
+func (s *FileWatchSource) scan(ctx context.Context, b Binding) error {
+	dir, err := fsutil.ResolvePath(s.root, b.Config.Directory)
+	if err != nil {
+		return err
+	}
+	// Ledger paths are relative to the root as ResolvePath sees it: absolute,
+	// with symlinks resolved
+	root, err := fsutil.ResolvePath(s.root, "")
+	if err != nil {
+		return err
+	}
+	pattern := b.Config.Pattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	debounce := defaultFileWatchDebounce
+	if b.Config.Debounce != "" {
+		if d, err := time.ParseDuration(b.Config.Debounce); err == nil {
+			debounce = d
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	ledger, err := s.loadLedger(ctx, b)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	prefix := b.WorkflowID + "/" + b.NodeID + "/"
+	seenKeys := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, entry.Name()); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		fullPath := filepath.Join(dir, entry.Name())
+		relPath, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			continue
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		seen, processed := ledger[relPath]
+		if processed && seen.size == info.Size() && seen.modTime.Equal(info.ModTime().UTC().Truncate(time.Microsecond)) {
+			continue
+		}
+
+		// Debounce: only pick the file up once it has stopped changing, so
+		// half-written uploads are not ingested
+		key := prefix + relPath
+		seenKeys[key] = true
+		s.mu.Lock()
+		p, ok := s.pending[key]
+		if !ok || p.size != info.Size() || !p.modTime.Equal(info.ModTime()) {
+			s.pending[key] = pendingFile{size: info.Size(), modTime: info.ModTime(), firstSeen: now}
+			s.mu.Unlock()
+			continue
+		}
+		if now.Sub(p.firstSeen) < debounce {
+			s.mu.Unlock()
+			continue
+		}
+		delete(s.pending, key)
+		s.mu.Unlock()
+
+		event := "created"
+		if processed {
+			event = "modified"
+		}
+		var previous *ledgerEntry
+		if processed {
+			previous = &seen
+		}
+		if err := s.process(ctx, b, fullPath, relPath, info, event, previous); err != nil {
+			log.Printf("[Trigger] file_watch failed to process %s: %v", relPath, err)
+		}
+	}
+
+	// Forget files that were deleted or renamed while still settling
+	s.mu.Lock()
+	for key := range s.pending {
+		if strings.HasPrefix(key, prefix) && !seenKeys[key] {
+			delete(s.pending, key)
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// process records the file in the ledger before starting the execution, so a
+// crash in between skips the file rather than starting it twice. previous is
+// the ledger entry being replaced, if any; it is restored when the start fails
+// so the next scan tries again.
+func (s *FileWatchSource) process(ctx context.Context, b Binding, fullPath, relPath string, info os.FileInfo, event string, previous *ledgerEntry) error {
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"event":       event,
+		"path":        relPath,
+		"name":        info.Name(),
+		"size":        info.Size(),
+		"modified_at": info.ModTime().UTC(),
+	}
+	if len(data) <= maxInlineFileSize && utf8.Valid(data) {
+		payload["content"] = string(data)
+	} else {
+		ref, err := s.store.Put(ctx, info.Name(), "", data)
+		if err != nil {
+			return err
+		}
+		payload["binary"] = ref
+	}
+
+	// The claim only succeeds if no other replica has recorded this version
+	// of the file yet
+	modTime := info.ModTime().UTC().Truncate(time.Microsecond)
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO file_watch_ledger (workflow_id, node_id, path, size, mod_time, execution_id, processed_at)
+		VALUES ($1, $2, $3, $4, $5, NULL, $6)
+		ON CONFLICT (workflow_id, node_id, path)
+		DO UPDATE SET size = EXCLUDED.size, mod_time = EXCLUDED.mod_time, processed_at = EXCLUDED.processed_at
+		WHERE file_watch_ledger.size <> EXCLUDED.size OR file_watch_ledger.mod_time <> EXCLUDED.mod_time`,
+		b.WorkflowID, b.NodeID, relPath, info.Size(), modTime, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+
+	execID, err := s.starter.StartExecutionWithPayload(ctx, b.WorkflowID, payload)
+	if err != nil {
+		s.releaseClaim(ctx, b, relPath, previous)
+		return err
+	}
+	log.Printf("[Trigger] started execution %s of workflow %s for file %s", execID, b.WorkflowID, relPath)
+
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE file_watch_ledger SET execution_id = $4 WHERE workflow_id = $1 AND node_id = $2 AND path = $3`,
+		b.WorkflowID, b.NodeID, relPath, execID)
+	return err
+}
+
+// releaseClaim puts the ledger back as it was before process claimed the file
+func (s *FileWatchSource) releaseClaim(ctx context.Context, b Binding, relPath string, previous *ledgerEntry) {
+	var err error
+	if previous == nil {
+		_, err = s.db.ExecContext(ctx,
+			`DELETE FROM file_watch_ledger WHERE workflow_id = $1 AND node_id = $2 AND path = $3`,
+			b.WorkflowID, b.NodeID, relPath)
+	} else {
+		_, err = s.db.ExecContext(ctx,
+			`UPDATE file_watch_ledger SET size = $4, mod_time = $5 WHERE workflow_id = $1 AND node_id = $2 AND path = $3`,
+			b.WorkflowID, b.NodeID, relPath, previous.size, previous.modTime)
+	}
+	if err != nil {
+		log.Printf("[Trigger] file_watch failed to release %s, it will not be retried: %v", relPath, err)
+	}
+}
+
+func (s *FileWatchSource) loadLedger(ctx context.Context, b Binding) (map[string]ledgerEntry, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT path, size, mod_time FROM file_watch_ledger WHERE workflow_id = $1 AND node_id = $2`,
+		b.WorkflowID, b.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ledger := make(map[string]ledgerEntry)
+	for rows.Next() {
+		var path string
+		var entry ledgerEntry
+		if err := rows.Scan(&path, &entry.size, &entry.modTime); err != nil {
+			return nil, err
+		}
+		entry.modTime = entry.modTime.UTC()
+		ledger[path] = entry
+	}
+	return ledger, rows.Err()
+}
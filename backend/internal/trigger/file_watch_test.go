@@ -0,0 +1,148 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// starterFunc adapts a function to Starter
+type starterFunc func(ctx context.Context, workflowID string, payload interface{}) (string, error)
+
+func (f starterFunc) StartExecutionWithPayload(ctx context.Context, workflowID string, payload interface{}) (string, error) {
+	return f(ctx, workflowID, payload)
+}
+
+// newFileWatchTest returns a source whose root holds in/report.csv, and a
+// binding that watches it without debounce
+func newFileWatchTest(t *testing.T, starter Starter) (*FileWatchSource, sqlmock.Sqlmock, Binding) {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "in"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "in", "report.csv"), []byte("a,b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	src := &FileWatchSource{root: root, db: db, starter: starter, pending: make(map[string]pendingFile)}
+	b := Binding{WorkflowID: "wf", NodeID: "start", Config: models.TriggerConfig{Directory: "in", Debounce: "0s"}}
+	return src, mock, b
+}
+
+func expectEmptyLedger(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM file_watch_ledger").WithArgs("wf", "start").
+		WillReturnRows(sqlmock.NewRows([]string{"path", "size", "mod_time"}))
+}
+
+// scanTwice sees the file once and picks it up on the second scan
+func scanTwice(t *testing.T, src *FileWatchSource, mock sqlmock.Sqlmock, b Binding) {
+	t.Helper()
+	for i := 0; i < 2; i++ {
+		expectEmptyLedger(mock)
+		if i == 1 {
+			mock.ExpectExec("INSERT INTO file_watch_ledger").WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		if err := src.scan(context.Background(), b); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileWatchRecordsLedgerBeforeStart(t *testing.T) {
+	var mock sqlmock.Sqlmock
+	started := 0
+	src, mock, b := newFileWatchTest(t, starterFunc(func(context.Context, string, interface{}) (string, error) {
+		started++
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("execution started before the ledger row was written: %v", err)
+		}
+		mock.ExpectExec("UPDATE file_watch_ledger SET execution_id").
+			WithArgs("wf", "start", "in/report.csv", "exec-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		return "exec-1", nil
+	}))
+
+	scanTwice(t, src, mock, b)
+	if started != 1 {
+		t.Errorf("started %d executions, want 1", started)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileWatchReleasesClaimWhenStartFails(t *testing.T) {
+	var mock sqlmock.Sqlmock
+	src, mock, b := newFileWatchTest(t, starterFunc(func(context.Context, string, interface{}) (string, error) {
+		mock.ExpectExec("DELETE FROM file_watch_ledger").
+			WithArgs("wf", "start", "in/report.csv").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		return "", errors.New("temporal unavailable")
+	}))
+
+	scanTwice(t, src, mock, b)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileWatchSkipsFileClaimedElsewhere(t *testing.T) {
+	started := 0
+	src, mock, b := newFileWatchTest(t, starterFunc(func(context.Context, string, interface{}) (string, error) {
+		started++
+		return "exec-1", nil
+	}))
+
+	expectEmptyLedger(mock)
+	if err := src.scan(context.Background(), b); err != nil {
+		t.Fatal(err)
+	}
+	expectEmptyLedger(mock)
+	mock.ExpectExec("INSERT INTO file_watch_ledger").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := src.scan(context.Background(), b); err != nil {
+		t.Fatal(err)
+	}
+	if started != 0 {
+		t.Errorf("started %d executions for a file another replica claimed", started)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFileWatchForgetsRemovedPendingFiles(t *testing.T) {
+	src, mock, b := newFileWatchTest(t, starterFunc(func(context.Context, string, interface{}) (string, error) {
+		t.Error("no execution should start")
+		return "", nil
+	}))
+
+	expectEmptyLedger(mock)
+	if err := src.scan(context.Background(), b); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.pending) != 1 {
+		t.Fatalf("%d pending files, want the new file", len(src.pending))
+	}
+
+	if err := os.Remove(filepath.Join(src.root, "in", "report.csv")); err != nil {
+		t.Fatal(err)
+	}
+	expectEmptyLedger(mock)
+	if err := src.scan(context.Background(), b); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.pending) != 0 {
+		t.Errorf("pending = %v, want the removed file forgotten", src.pending)
+	}
+}
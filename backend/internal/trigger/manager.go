@@ -0,0 +1,138 @@
+package trigger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/pkg/dag"
+)
+
+// Starter starts a workflow execution for a trigger event
+type Starter interface {
+	StartExecutionWithPayload(ctx context.Context, workflowID string, payload interface{}) (string, error)
+}
+
+// Binding ties a workflow to the trigger configured on one of its start nodes
+type Binding struct {
+	WorkflowID string
+	NodeID     string
+	Config     models.TriggerConfig
+}
+
+// Source runs the listeners for a single trigger type
+type Source interface {
+	// Type returns the trigger type this source handles
+	Type() string
+	// Sync replaces the active bindings of this source
+	Sync(ctx context.Context, bindings []Binding) error
+	// Close stops all listeners
+	Close() error
+}
+
+// Manager periodically loads trigger bindings from saved workflows and hands
+// them to the matching sources, so edits take effect without a restart
+type Manager struct {
+	DB       *sql.DB
+	Interval time.Duration
+	sources  map[string]Source
+}
+
+func NewManager(db *sql.DB, interval time.Duration, sources ...Source) *Manager {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	m := &Manager{DB: db, Interval: interval, sources: make(map[string]Source)}
+	for _, src := range sources {
+		m.sources[src.Type()] = src
+	}
+	return m
+}
+
+// Run syncs bindings until the context is cancelled, then closes all sources
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sync(ctx); err != nil {
+			log.Printf("[Trigger] sync failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, src := range m.sources {
+				if err := src.Close(); err != nil {
+					log.Printf("[Trigger] failed to close %s source: %v", src.Type(), err)
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync loads the current bindings and distributes them to the sources
+func (m *Manager) Sync(ctx context.Context) error {
+	bindings, err := m.loadBindings(ctx)
+	if err != nil {
+		return err
+	}
+
+	byType := make(map[string][]Binding)
+	for _, b := range bindings {
+		byType[b.Config.Type] = append(byType[b.Config.Type], b)
+	}
+
+	for triggerType, src := range m.sources {
+		if err := src.Sync(ctx, byType[triggerType]); err != nil {
+			log.Printf("[Trigger] failed to sync %s source: %v", triggerType, err)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) loadBindings(ctx context.Context) ([]Binding, error) {
+	rows, err := m.DB.QueryContext(ctx, `SELECT id, dag_json FROM workflows`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bindings []Binding
+	for rows.Next() {
+		var workflowID, dagJSON string
+		if err := rows.Scan(&workflowID, &dagJSON); err != nil {
+			return nil, err
+		}
+
+		var dagStruct models.DAGStructure
+		if err := json.Unmarshal([]byte(dagJSON), &dagStruct); err != nil {
+			log.Printf("[Trigger] skipping workflow %s: invalid dag: %v", workflowID, err)
+			continue
+		}
+
+		for _, node := range dagStruct.Nodes {
+			if node.Type != "start" || node.Data == nil {
+				continue
+			}
+			startData, err := dag.ParseStartNodeData(node.Data)
+			if err != nil || startData.Trigger == nil {
+				continue
+			}
+			if startData.Trigger.Type == "" || startData.Trigger.Type == models.TriggerTypeManual {
+				continue
+			}
+			bindings = append(bindings, Binding{
+				WorkflowID: workflowID,
+				NodeID:     node.ID,
+				Config:     *startData.Trigger,
+			})
+		}
+	}
+
+	return bindings, rows.Err()
+}
@@ -0,0 +1,142 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// NATSSource starts an execution for every message received on the subjects
+// of nats triggers. Each binding subscribes in a queue group, so API
+// replicas share the messages instead of each starting an execution.
+type NATSSource struct {
+	conn    *nats.Conn
+	starter Starter
+
+	mu   sync.Mutex
+	subs map[natsBinding]*nats.Subscription
+}
+
+type natsBinding struct {
+	workflowID string
+	subject    string
+	queue      string
+}
+
+// NewNATSSource connects to the servers in url (comma separated), retrying in
+// the background when they are not reachable yet
+func NewNATSSource(url string, starter Starter) (*NATSSource, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("n8n-clone triggers"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Printf("[Trigger] nats disconnected: %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(c *nats.Conn) {
+			log.Printf("[Trigger] nats reconnected to %s", c.ConnectedUrlRedacted())
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &NATSSource{
+		conn:    conn,
+		starter: starter,
+		subs:    make(map[natsBinding]*nats.Subscription),
+	}, nil
+}
+
+func (s *NATSSource) Type() string {
+	return models.TriggerTypeNATS
+}
+
+// Sync subscribes newly configured bindings and drains removed ones
+func (s *NATSSource) Sync(ctx context.Context, bindings []Binding) error {
+	next := make(map[natsBinding]bool)
+	for _, b := range bindings {
+		subject := strings.TrimSpace(b.Config.Subject)
+		if subject == "" {
+			continue
+		}
+		queue := strings.TrimSpace(b.Config.Queue)
+		if queue == "" {
+			queue = "workflow." + b.WorkflowID
+		}
+		next[natsBinding{workflowID: b.WorkflowID, subject: subject, queue: queue}] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range next {
+		if _, ok := s.subs[key]; ok {
+			continue
+		}
+		workflowID := key.workflowID
+		sub, err := s.conn.QueueSubscribe(key.subject, key.queue, func(msg *nats.Msg) {
+			s.handle(workflowID, msg)
+		})
+		if err != nil {
+			log.Printf("[Trigger] failed to subscribe workflow %s to %q: %v", key.workflowID, key.subject, err)
+			continue
+		}
+		s.subs[key] = sub
+	}
+	for key, sub := range s.subs {
+		if next[key] {
+			continue
+		}
+		// Drain lets messages already delivered start their executions
+		if err := sub.Drain(); err != nil {
+			log.Printf("[Trigger] failed to unsubscribe workflow %s from %q: %v", key.workflowID, key.subject, err)
+		}
+		delete(s.subs, key)
+	}
+	return nil
+}
+
+// Close drains all subscriptions and the connection
+func (s *NATSSource) Close() error {
+	return s.conn.Drain()
+}
+
+// handle starts one execution with {"subject", "reply", "headers", "data"},
+// where data is the parsed JSON body or the raw text. Requests are answered
+// with the execution ID or the error.
+func (s *NATSSource) handle(workflowID string, msg *nats.Msg) {
+	payload := map[string]interface{}{
+		"subject": msg.Subject,
+		"reply":   msg.Reply,
+		"headers": map[string][]string(msg.Header),
+	}
+	var data interface{}
+	if err := json.Unmarshal(msg.Data, &data); err == nil {
+		payload["data"] = data
+	} else {
+		payload["data"] = string(msg.Data)
+	}
+
+	execID, err := s.starter.StartExecutionWithPayload(context.Background(), workflowID, payload)
+	reply := map[string]string{"execution_id": execID}
+	if err != nil {
+		log.Printf("[Trigger] failed to start workflow %s from subject %q: %v", workflowID, msg.Subject, err)
+		reply = map[string]string{"error": err.Error()}
+	} else {
+		log.Printf("[Trigger] started execution %s of workflow %s from subject %q", execID, workflowID, msg.Subject)
+	}
+	if msg.Reply != "" {
+		body, _ := json.Marshal(reply)
+		if err := msg.Respond(body); err != nil {
+			log.Printf("[Trigger] failed to reply on %q: %v", msg.Reply, err)
+		}
+	}
+}
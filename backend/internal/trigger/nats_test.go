@@ -0,0 +1,210 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// newTestNATSServer starts an in-process NATS server on a random port
+func newTestNATSServer(t *testing.T) *server.Server {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	srv := natsserver.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+// recordingStarter records started executions per workflow
+type recordingStarter struct {
+	mu       sync.Mutex
+	payloads map[string][]interface{}
+	err      error
+}
+
+func (s *recordingStarter) StartExecutionWithPayload(_ context.Context, workflowID string, payload interface{}) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.payloads == nil {
+		s.payloads = make(map[string][]interface{})
+	}
+	s.payloads[workflowID] = append(s.payloads[workflowID], payload)
+	return fmt.Sprintf("%s-%d", workflowID, len(s.payloads[workflowID])), nil
+}
+
+func (s *recordingStarter) count(workflowID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.payloads[workflowID])
+}
+
+func newTestNATSSource(t *testing.T, url string, starter Starter) *NATSSource {
+	t.Helper()
+	src, err := NewNATSSource(url, starter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { src.Close() })
+	return src
+}
+
+func natsBindingFor(workflowID, subject, queue string) Binding {
+	return Binding{WorkflowID: workflowID, NodeID: "start", Config: models.TriggerConfig{Subject: subject, Queue: queue}}
+}
+
+// flush waits until the server has processed everything c sent
+func flush(t *testing.T, c *nats.Conn) {
+	t.Helper()
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls until cond holds or a second has passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNATSSourceQueueGroups(t *testing.T) {
+	srv := newTestNATSServer(t)
+	starter := &recordingStarter{}
+
+	// Two API replicas serving the same workflows, plus a second workflow on
+	// the same subject
+	replicas := []*NATSSource{
+		newTestNATSSource(t, srv.ClientURL(), starter),
+		newTestNATSSource(t, srv.ClientURL(), starter),
+	}
+	bindings := []Binding{
+		natsBindingFor("wf-a", "orders.*", ""),
+		natsBindingFor("wf-b", "orders.created", ""),
+	}
+	for _, src := range replicas {
+		if err := src.Sync(context.Background(), bindings); err != nil {
+			t.Fatal(err)
+		}
+		flush(t, src.conn)
+	}
+
+	pub, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+	const n = 10
+	for i := 0; i < n; i++ {
+		if err := pub.Publish("orders.created", []byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	flush(t, pub)
+
+	// Each workflow starts once per message, not once per replica
+	waitFor(t, "executions", func() bool { return starter.count("wf-a") >= n && starter.count("wf-b") >= n })
+	time.Sleep(50 * time.Millisecond)
+	if a, b := starter.count("wf-a"), starter.count("wf-b"); a != n || b != n {
+		t.Errorf("started wf-a %d and wf-b %d times, want %d each", a, b, n)
+	}
+
+	payload := starter.payloads["wf-a"][0].(map[string]interface{})
+	if payload["subject"] != "orders.created" {
+		t.Errorf("subject = %v, want orders.created", payload["subject"])
+	}
+	if _, ok := payload["data"].(map[string]interface{}); !ok {
+		t.Errorf("data = %#v, want the parsed JSON body", payload["data"])
+	}
+}
+
+func TestNATSSourceReply(t *testing.T) {
+	srv := newTestNATSServer(t)
+	starter := &recordingStarter{}
+	src := newTestNATSSource(t, srv.ClientURL(), starter)
+	if err := src.Sync(context.Background(), []Binding{natsBindingFor("wf", "jobs", "workers")}); err != nil {
+		t.Fatal(err)
+	}
+	flush(t, src.conn)
+
+	client, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	msg, err := client.Request("jobs", []byte("plain text"), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reply map[string]string
+	if err := json.Unmarshal(msg.Data, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply["execution_id"] != "wf-1" {
+		t.Errorf("reply = %v, want execution_id wf-1", reply)
+	}
+	if data := starter.payloads["wf"][0].(map[string]interface{})["data"]; data != "plain text" {
+		t.Errorf("data = %#v, want the raw text", data)
+	}
+
+	starter.mu.Lock()
+	starter.err = errors.New("workflow is inactive")
+	starter.mu.Unlock()
+	if msg, err = client.Request("jobs", nil, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(msg.Data, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply["error"] != "workflow is inactive" {
+		t.Errorf("reply = %v, want the start error", reply)
+	}
+}
+
+func TestNATSSourceSyncRemovesBindings(t *testing.T) {
+	srv := newTestNATSServer(t)
+	starter := &recordingStarter{}
+	src := newTestNATSSource(t, srv.ClientURL(), starter)
+	ctx := context.Background()
+	if err := src.Sync(ctx, []Binding{natsBindingFor("wf", "events", "")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Sync(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.subs) != 0 {
+		t.Fatalf("%d subscriptions left after removing every binding", len(src.subs))
+	}
+	flush(t, src.conn)
+
+	client, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Request("events", nil, time.Second); !errors.Is(err, nats.ErrNoResponders) {
+		t.Errorf("got %v, want ErrNoResponders once the binding is removed", err)
+	}
+	if n := starter.count("wf"); n != 0 {
+		t.Errorf("started %d executions after the binding was removed", n)
+	}
+}
@@ -0,0 +1,147 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+// postgresStartTimeout bounds starting one execution. Notifications are
+// handled one at a time, so a hung start would hold up every later one.
+const postgresStartTimeout = 30 * time.Second
+
+// PostgresSource starts executions for notifications received through
+// Postgres LISTEN/NOTIFY
+type PostgresSource struct {
+	starter  Starter
+	listener *pq.Listener
+
+	mu       sync.Mutex
+	channels map[string][]string // channel -> workflow IDs
+	done     chan struct{}
+}
+
+// NewPostgresSource opens a dedicated listener connection to the database
+func NewPostgresSource(databaseURL string, starter Starter) *PostgresSource {
+	s := &PostgresSource{
+		starter:  starter,
+		channels: make(map[string][]string),
+		done:     make(chan struct{}),
+	}
+	s.listener = pq.NewListener(databaseURL, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("[Trigger] postgres listener event %d: %v", event, err)
+		}
+	})
+	go s.loop()
+	return s
+}
+
+func (s *PostgresSource) Type() string {
+	return models.TriggerTypePostgres
+}
+
+// Sync listens on newly configured channels and unlistens removed ones
+func (s *PostgresSource) Sync(ctx context.Context, bindings []Binding) error {
+	next := make(map[string][]string)
+	for _, b := range bindings {
+		channel := strings.TrimSpace(b.Config.Channel)
+		if channel == "" {
+			continue
+		}
+		next[channel] = append(next[channel], b.WorkflowID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for channel := range next {
+		if _, ok := s.channels[channel]; ok {
+			continue
+		}
+		if err := s.listener.Listen(channel); err != nil && err != pq.ErrChannelAlreadyOpen {
+			log.Printf("[Trigger] failed to listen on channel %q: %v", channel, err)
+			delete(next, channel)
+		}
+	}
+	for channel := range s.channels {
+		if _, ok := next[channel]; ok {
+			continue
+		}
+		if err := s.listener.Unlisten(channel); err != nil && err != pq.ErrChannelNotOpen {
+			log.Printf("[Trigger] failed to unlisten channel %q: %v", channel, err)
+		}
+	}
+
+	s.channels = next
+	return nil
+}
+
+func (s *PostgresSource) Close() error {
+	close(s.done)
+	return s.listener.Close()
+}
+
+func (s *PostgresSource) loop() {
+	// Ping periodically so a silently dropped connection is noticed and re-established
+	ticker := time.NewTicker(90 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case n, ok := <-s.listener.Notify:
+			if !ok {
+				return
+			}
+			// A nil notification signals a reconnect; anything sent meanwhile is lost
+			if n == nil {
+				continue
+			}
+			s.handle(n)
+		case <-ticker.C:
+			go func() {
+				if err := s.listener.Ping(); err != nil {
+					log.Printf("[Trigger] postgres listener ping failed: %v", err)
+				}
+			}()
+		}
+	}
+}
+
+func (s *PostgresSource) handle(n *pq.Notification) {
+	s.mu.Lock()
+	workflowIDs := append([]string(nil), s.channels[n.Channel]...)
+	s.mu.Unlock()
+
+	payload := map[string]interface{}{
+		"channel": n.Channel,
+		"pid":     n.BePid,
+	}
+	// Payloads produced by workflow_notify() are JSON; anything else is passed through as text
+	var data interface{}
+	if err := json.Unmarshal([]byte(n.Extra), &data); err == nil {
+		payload["data"] = data
+	} else {
+		payload["data"] = n.Extra
+	}
+
+	for _, workflowID := range workflowIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), postgresStartTimeout)
+		execID, err := s.starter.StartExecutionWithPayload(ctx, workflowID, payload)
+		cancel()
+		if err != nil {
+			log.Printf("[Trigger] failed to start workflow %s from channel %q: %v", workflowID, n.Channel, err)
+			continue
+		}
+		log.Printf("[Trigger] started execution %s of workflow %s from channel %q", execID, workflowID, n.Channel)
+	}
+}
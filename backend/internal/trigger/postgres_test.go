@@ -0,0 +1,43 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// deadlineStarter records whether each start had a deadline
+type deadlineStarter struct {
+	recordingStarter
+	deadlines []bool
+}
+
+func (s *deadlineStarter) StartExecutionWithPayload(ctx context.Context, workflowID string, payload interface{}) (string, error) {
+	_, ok := ctx.Deadline()
+	s.deadlines = append(s.deadlines, ok)
+	return s.recordingStarter.StartExecutionWithPayload(ctx, workflowID, payload)
+}
+
+func TestPostgresSourceHandle(t *testing.T) {
+	starter := &deadlineStarter{}
+	src := &PostgresSource{starter: starter, channels: map[string][]string{"orders_changed": {"wf-a", "wf-b"}}}
+
+	src.handle(&pq.Notification{Channel: "orders_changed", BePid: 7, Extra: `{"operation":"INSERT"}`})
+	src.handle(&pq.Notification{Channel: "orders_changed", Extra: "plain"})
+
+	if a, b := starter.count("wf-a"), starter.count("wf-b"); a != 2 || b != 2 {
+		t.Fatalf("started wf-a %d and wf-b %d times, want twice each", a, b)
+	}
+	for i, ok := range starter.deadlines {
+		if !ok {
+			t.Errorf("start %d had no deadline", i)
+		}
+	}
+	if data, _ := starter.payloads["wf-a"][0].(map[string]interface{})["data"].(map[string]interface{}); data["operation"] != "INSERT" {
+		t.Errorf("data = %v, want the parsed JSON payload", data)
+	}
+	if data := starter.payloads["wf-a"][1].(map[string]interface{})["data"]; data != "plain" {
+		t.Errorf("data = %#v, want the raw text", data)
+	}
+}
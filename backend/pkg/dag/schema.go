@@ -0,0 +1,108 @@
+package dag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const inputSchemaURL = "mem:///workflow-input.json"
+
+// FieldError describes why a single input field failed validation
+type FieldError struct {
+	Field   string `json:"field"` // JSON pointer into the payload, e.g. "/customer/email"
+	Message string `json:"message"`
+}
+
+// CompileInputSchema compiles a workflow's declared input JSON Schema
+func CompileInputSchema(schema interface{}) (*jsonschema.Schema, error) {
+	doc, err := toSchemaValue(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(noExternalRefs{})
+	if err := compiler.AddResource(inputSchemaURL, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(inputSchemaURL)
+}
+
+// noExternalRefs refuses to load any $ref outside the schema itself. The
+// default loader would read local files (and the compile also runs inside
+// workflow code), so schemas may only reference their own definitions.
+type noExternalRefs struct{}
+
+func (noExternalRefs) Load(url string) (any, error) {
+	return nil, fmt.Errorf("external $ref %q is not allowed", url)
+}
+
+// ValidateInput checks a run payload against a workflow's input schema and
+// returns one FieldError per failing field
+func ValidateInput(schema interface{}, payload interface{}) ([]FieldError, error) {
+	compiled, err := CompileInputSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input schema: %w", err)
+	}
+	instance, err := toSchemaValue(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiled.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	printer := message.NewPrinter(language.English)
+	var fieldErrors []FieldError
+	collectFieldErrors(validationErr, printer, &fieldErrors)
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+	return fieldErrors, nil
+}
+
+func collectFieldErrors(e *jsonschema.ValidationError, printer *message.Printer, out *[]FieldError) {
+	if len(e.Causes) > 0 {
+		for _, cause := range e.Causes {
+			collectFieldErrors(cause, printer, out)
+		}
+		return
+	}
+
+	location := "/" + strings.Join(e.InstanceLocation, "/")
+	// Report missing required properties against the property itself
+	if required, ok := e.ErrorKind.(*kind.Required); ok {
+		for _, name := range required.Missing {
+			*out = append(*out, FieldError{
+				Field:   strings.TrimSuffix(location, "/") + "/" + name,
+				Message: "is required",
+			})
+		}
+		return
+	}
+	*out = append(*out, FieldError{Field: location, Message: e.ErrorKind.LocalizedString(printer)})
+}
+
+// toSchemaValue round-trips v through the library's JSON decoder so numbers
+// are represented the way the validator expects
+func toSchemaValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(b))
+}
@@ -0,0 +1,48 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompileInputSchemaRejectsExternalRefs(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "secret.json")
+	if err := os.WriteFile(secret, []byte(`{"const": "s3cr3t-value"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{"file://" + secret, "http://127.0.0.1:1/schema.json"} {
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"x": map[string]interface{}{"$ref": ref}},
+		}
+		_, err := CompileInputSchema(schema)
+		if err == nil {
+			t.Errorf("%s: compiled, want the $ref rejected", ref)
+			continue
+		}
+		if strings.Contains(err.Error(), "s3cr3t-value") {
+			t.Errorf("%s: error leaks the file content: %v", ref, err)
+		}
+		if _, err := ValidateInput(schema, map[string]interface{}{"x": "guess"}); err == nil {
+			t.Errorf("%s: ValidateInput accepted the schema", ref)
+		}
+	}
+}
+
+func TestCompileInputSchemaAllowsLocalRefs(t *testing.T) {
+	schema := map[string]interface{}{
+		"$defs":      map[string]interface{}{"email": map[string]interface{}{"type": "string", "minLength": 3}},
+		"type":       "object",
+		"properties": map[string]interface{}{"email": map[string]interface{}{"$ref": "#/$defs/email"}},
+	}
+	fieldErrors, err := ValidateInput(schema, map[string]interface{}{"email": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fieldErrors) != 1 || fieldErrors[0].Field != "/email" {
+		t.Errorf("field errors = %+v, want one for /email", fieldErrors)
+	}
+}
@@ -0,0 +1,43 @@
+package dag
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// Template is a parsed text/template or html/template
+type Template interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// excludedTemplateFuncs are the sprig helpers templates may not call: they
+// read the worker's environment or network, allocate as much as an argument
+// asks for, or burn CPU generating keys and hashes
+var excludedTemplateFuncs = []string{
+	"env", "expandenv", "getHostByName",
+	"repeat", "until", "untilStep", "seq",
+	"randAlphaNum", "randAlpha", "randAscii", "randNumeric", "randBytes",
+	"genPrivateKey", "derivePassword", "bcrypt", "htpasswd", "buildCustomCert",
+	"genCA", "genCAWithKey", "genSelfSignedCert", "genSelfSignedCertWithKey",
+	"genSignedCert", "genSignedCertWithKey",
+}
+
+// templateFuncs returns the sprig helpers minus excludedTemplateFuncs
+func templateFuncs(funcs map[string]interface{}) map[string]interface{} {
+	for _, name := range excludedTemplateFuncs {
+		delete(funcs, name)
+	}
+	return funcs
+}
+
+// ParseTemplate parses a template node's source. Engine "html" selects
+// html/template with contextual escaping; anything else uses text/template.
+func ParseTemplate(engine, source string) (Template, error) {
+	if engine == "html" {
+		return htmltemplate.New("template").Funcs(templateFuncs(sprig.HtmlFuncMap())).Parse(source)
+	}
+	return template.New("template").Funcs(templateFuncs(sprig.TxtFuncMap())).Parse(source)
+}
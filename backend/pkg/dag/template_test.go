@@ -0,0 +1,16 @@
+package dag
+
+import "testing"
+
+func TestParseTemplateExcludedFuncs(t *testing.T) {
+	for _, engine := range []string{"text", "html"} {
+		for _, name := range excludedTemplateFuncs {
+			if _, err := ParseTemplate(engine, "{{ "+name+" }}"); err == nil {
+				t.Errorf("%s template: %s parsed, want it undefined", engine, name)
+			}
+		}
+		if _, err := ParseTemplate(engine, `{{ "a b" | title | upper }}`); err != nil {
+			t.Errorf("%s template: %v, want the other helpers available", engine, err)
+		}
+	}
+}
@@ -4,9 +4,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xpath"
 
 	"github.com/your-org/n8n-clone/internal/db/models"
+	"github.com/your-org/n8n-clone/pkg/protoset"
 )
 
 // ValidationResult holds validation results
@@ -17,6 +28,17 @@ type ValidationResult struct {
 
 // ValidateDAG validates a workflow DAG structure
 func ValidateDAG(dag *models.DAGStructure) ValidationResult {
+	return validateDAG(dag, true)
+}
+
+// ValidateDAGWithoutSourceHandles validates like ValidateDAG but accepts edges
+// from outputs their source does not emit, as runs started before that check
+// did
+func ValidateDAGWithoutSourceHandles(dag *models.DAGStructure) ValidationResult {
+	return validateDAG(dag, false)
+}
+
+func validateDAG(dag *models.DAGStructure, checkSourceHandles bool) ValidationResult {
 	result := ValidationResult{Valid: true, Errors: []string{}}
 
 	// Check for at least one start node
@@ -62,6 +84,47 @@ func ValidateDAG(dag *models.DAGStructure) ValidationResult {
 		}
 	}
 
+	// Check the declared input schema compiles
+	if dag.InputSchema != nil {
+		if _, err := CompileInputSchema(dag.InputSchema); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("Invalid input schema: %v", err))
+		}
+	}
+
+	// Check the default timezone for date nodes
+	if dag.Timezone != "" {
+		if _, err := time.LoadLocation(dag.Timezone); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("Invalid timezone '%s'", dag.Timezone))
+		}
+	}
+
+	// Batch bodies run once per chunk and cannot nest
+	for _, batchNode := range filterNodesByType(dag.Nodes, "batch") {
+		for id := range BatchBody(batchNode.ID, dag.Nodes, dag.Edges) {
+			if node := GetNodeByID(id, dag.Nodes); node != nil && node.Type == "batch" {
+				result.Valid = false
+				result.Errors = append(result.Errors, fmt.Sprintf("Batch node '%s' cannot be nested inside batch node '%s'", id, batchNode.ID))
+			}
+		}
+	}
+
+	// Edges may only leave through outputs their source node emits
+	for _, edge := range dag.Edges {
+		if !checkSourceHandles || edge.SourceHandle == "" {
+			continue
+		}
+		node := GetNodeByID(edge.Source, dag.Nodes)
+		if node == nil {
+			continue
+		}
+		if !slices.Contains(sourceHandles(*node), edge.SourceHandle) {
+			result.Valid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("Edge '%s' uses unknown output '%s' of %s node '%s'", edge.ID, edge.SourceHandle, node.Type, node.ID))
+		}
+	}
+
 	// Validate node-specific data
 	for _, node := range dag.Nodes {
 		nodeErrors := validateNodeData(node)
@@ -127,6 +190,71 @@ func GetNodeByID(nodeID string, nodes []models.Node) *models.Node {
 	return nil
 }
 
+// sourceHandles lists the named outputs a node emits besides its default one
+func sourceHandles(node models.Node) []string {
+	switch node.Type {
+	case "filter":
+		var filterData models.FilterNodeData
+		if err := DecodeNodeData(node.Data, &filterData); err == nil && filterData.EmitDiscarded {
+			return []string{"kept", "discarded"}
+		}
+		return []string{"kept"}
+	case "graphql":
+		return []string{"errors"}
+	case "batch":
+		return []string{"done"}
+	}
+	return nil
+}
+
+// BatchBody returns the nodes executed once per chunk of a batch node: every
+// node reachable from it, stopping at output nodes and at nodes fed through
+// its "done" handle
+func BatchBody(batchID string, nodes []models.Node, edges []models.Edge) map[string]bool {
+	graph := make(map[string][]string)
+	var doneTargets []string
+	for _, edge := range edges {
+		if edge.Source == batchID && edge.SourceHandle == "done" {
+			doneTargets = append(doneTargets, edge.Target)
+			continue
+		}
+		graph[edge.Source] = append(graph[edge.Source], edge.Target)
+	}
+
+	// Nodes after the done handle run once the batches are re-assembled
+	after := make(map[string]bool)
+	var markAfter func(string)
+	markAfter = func(id string) {
+		if after[id] {
+			return
+		}
+		after[id] = true
+		for _, next := range graph[id] {
+			markAfter(next)
+		}
+	}
+	for _, target := range doneTargets {
+		markAfter(target)
+	}
+
+	body := make(map[string]bool)
+	var visit func(string)
+	visit = func(id string) {
+		for _, next := range graph[id] {
+			if body[next] || after[next] || next == batchID {
+				continue
+			}
+			if node := GetNodeByID(next, nodes); node == nil || node.Type == "output" {
+				continue
+			}
+			body[next] = true
+			visit(next)
+		}
+	}
+	visit(batchID)
+	return body
+}
+
 // Helper functions
 
 func filterNodesByType(nodes []models.Node, nodeType string) []models.Node {
@@ -239,6 +367,10 @@ func validateNodeData(node models.Node) []string {
 			errors = append(errors, fmt.Sprintf("HTTP node '%s' has invalid method '%s'", node.ID, httpData.Method))
 		}
 
+		if httpData.ResponseFormat != "" && httpData.ResponseFormat != "text" && httpData.ResponseFormat != "file" {
+			errors = append(errors, fmt.Sprintf("HTTP node '%s' responseFormat must be 'text' or 'file'", node.ID))
+		}
+
 	case "code":
 		// Code nodes are optional - if data is nil, it's passthrough mode
 		if node.Data != nil {
@@ -248,8 +380,274 @@ func validateNodeData(node models.Node) []string {
 			}
 		}
 		// Code is optional, so empty code is valid (passthrough mode)
+	case "set":
+		var setData models.SetNodeData
+		if err := DecodeNodeData(node.Data, &setData); err != nil {
+			errors = append(errors, fmt.Sprintf("Set node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		for i, a := range setData.Assignments {
+			if strings.TrimSpace(a.Name) == "" {
+				errors = append(errors, fmt.Sprintf("Set node '%s' assignment %d requires a name", node.ID, i+1))
+			}
+			if !isValidSetType(a.Type) {
+				errors = append(errors, fmt.Sprintf("Set node '%s' assignment '%s' has invalid type '%s'", node.ID, a.Name, a.Type))
+			}
+		}
+	case "filter":
+		var filterData models.FilterNodeData
+		if err := DecodeNodeData(node.Data, &filterData); err != nil {
+			errors = append(errors, fmt.Sprintf("Filter node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateFilter(node.ID, &filterData)...)
+	case "items":
+		var itemsData models.ItemsNodeData
+		if err := DecodeNodeData(node.Data, &itemsData); err != nil {
+			errors = append(errors, fmt.Sprintf("Items node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateItemsOperations(node.ID, itemsData.Operations)...)
+	case "batch":
+		var batchData models.BatchNodeData
+		if err := DecodeNodeData(node.Data, &batchData); err != nil {
+			errors = append(errors, fmt.Sprintf("Batch node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if batchData.Size < 1 {
+			errors = append(errors, fmt.Sprintf("Batch node '%s' size must be at least 1", node.ID))
+		}
+		if batchData.Delay != "" {
+			if d, err := time.ParseDuration(batchData.Delay); err != nil || d < 0 {
+				errors = append(errors, fmt.Sprintf("Batch node '%s' has invalid delay '%s'", node.ID, batchData.Delay))
+			}
+		}
+	case "postgres":
+		var pgData models.PostgresNodeData
+		if err := DecodeNodeData(node.Data, &pgData); err != nil {
+			errors = append(errors, fmt.Sprintf("Postgres node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if strings.TrimSpace(pgData.Credential) == "" {
+			errors = append(errors, fmt.Sprintf("Postgres node '%s' requires a credential", node.ID))
+		}
+		if strings.TrimSpace(pgData.Query) == "" {
+			errors = append(errors, fmt.Sprintf("Postgres node '%s' requires a query", node.ID))
+		}
+		switch pgData.Mode {
+		case "", models.PostgresModeSingle, models.PostgresModeEach, models.PostgresModeTransaction:
+		default:
+			errors = append(errors, fmt.Sprintf("Postgres node '%s' has invalid mode '%s'", node.ID, pgData.Mode))
+		}
+		if pgData.RowLimit < 0 {
+			errors = append(errors, fmt.Sprintf("Postgres node '%s' row limit cannot be negative", node.ID))
+		}
+	case "redis":
+		var redisData models.RedisNodeData
+		if err := DecodeNodeData(node.Data, &redisData); err != nil {
+			errors = append(errors, fmt.Sprintf("Redis node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateRedis(node.ID, &redisData)...)
+	case "email":
+		var emailData models.EmailNodeData
+		if err := DecodeNodeData(node.Data, &emailData); err != nil {
+			errors = append(errors, fmt.Sprintf("Email node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if strings.TrimSpace(emailData.Credential) == "" {
+			errors = append(errors, fmt.Sprintf("Email node '%s' requires a credential", node.ID))
+		}
+		if len(emailData.To)+len(emailData.Cc)+len(emailData.Bcc) == 0 {
+			errors = append(errors, fmt.Sprintf("Email node '%s' requires at least one recipient", node.ID))
+		}
+		if strings.TrimSpace(emailData.Text) == "" && strings.TrimSpace(emailData.HTML) == "" {
+			errors = append(errors, fmt.Sprintf("Email node '%s' requires a text or HTML body", node.ID))
+		}
+	case "csv":
+		var csvData models.CSVNodeData
+		if err := DecodeNodeData(node.Data, &csvData); err != nil {
+			errors = append(errors, fmt.Sprintf("CSV node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if csvData.Mode != "parse" && csvData.Mode != "generate" {
+			errors = append(errors, fmt.Sprintf("CSV node '%s' mode must be 'parse' or 'generate'", node.ID))
+		}
+		if csvData.Delimiter != "" {
+			if r := []rune(csvData.Delimiter); len(r) != 1 || r[0] == '"' || r[0] == '\r' || r[0] == '\n' {
+				errors = append(errors, fmt.Sprintf("CSV node '%s' has invalid delimiter '%s'", node.ID, csvData.Delimiter))
+			}
+		}
+		if csvData.Output != "" && csvData.Output != "text" && csvData.Output != "file" {
+			errors = append(errors, fmt.Sprintf("CSV node '%s' output must be 'text' or 'file'", node.ID))
+		}
+	case "parse":
+		var parseData models.ParseNodeData
+		if err := DecodeNodeData(node.Data, &parseData); err != nil {
+			errors = append(errors, fmt.Sprintf("Parse node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateParse(node.ID, &parseData)...)
+	case "template":
+		var tmplData models.TemplateNodeData
+		if err := DecodeNodeData(node.Data, &tmplData); err != nil {
+			errors = append(errors, fmt.Sprintf("Template node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if tmplData.Engine != "" && tmplData.Engine != "text" && tmplData.Engine != "html" {
+			errors = append(errors, fmt.Sprintf("Template node '%s' engine must be 'text' or 'html'", node.ID))
+		}
+		if tmplData.Output != "" && tmplData.Output != "text" && tmplData.Output != "file" {
+			errors = append(errors, fmt.Sprintf("Template node '%s' output must be 'text' or 'file'", node.ID))
+		}
+		if strings.TrimSpace(tmplData.Template) == "" {
+			errors = append(errors, fmt.Sprintf("Template node '%s' requires a template", node.ID))
+		} else if _, err := ParseTemplate(tmplData.Engine, tmplData.Template); err != nil {
+			errors = append(errors, fmt.Sprintf("Template node '%s' has an invalid template: %v", node.ID, err))
+		}
+	case "datetime":
+		var dtData models.DatetimeNodeData
+		if err := DecodeNodeData(node.Data, &dtData); err != nil {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateDatetime(node.ID, &dtData)...)
+	case "crypto":
+		var cryptoData models.CryptoNodeData
+		if err := DecodeNodeData(node.Data, &cryptoData); err != nil {
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateCrypto(node.ID, &cryptoData)...)
+	case "jwt":
+		var jwtData models.JWTNodeData
+		if err := DecodeNodeData(node.Data, &jwtData); err != nil {
+			errors = append(errors, fmt.Sprintf("JWT node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateJWT(node.ID, &jwtData)...)
+	case "graphql":
+		var gqlData models.GraphQLNodeData
+		if err := DecodeNodeData(node.Data, &gqlData); err != nil {
+			errors = append(errors, fmt.Sprintf("GraphQL node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if strings.TrimSpace(gqlData.Endpoint) == "" {
+			errors = append(errors, fmt.Sprintf("GraphQL node '%s' requires an endpoint", node.ID))
+		}
+		if strings.TrimSpace(gqlData.Query) == "" {
+			errors = append(errors, fmt.Sprintf("GraphQL node '%s' requires a query", node.ID))
+		}
+	case "grpc":
+		var grpcData models.GrpcNodeData
+		if err := DecodeNodeData(node.Data, &grpcData); err != nil {
+			errors = append(errors, fmt.Sprintf("gRPC node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if strings.TrimSpace(grpcData.Address) == "" {
+			errors = append(errors, fmt.Sprintf("gRPC node '%s' requires an address", node.ID))
+		}
+		if _, _, ok := protoset.SplitMethod(grpcData.Method); !ok {
+			errors = append(errors, fmt.Sprintf("gRPC node '%s' method must be a fully-qualified name like 'pkg.Service/Method'", node.ID))
+		}
+		if grpcData.Request != nil {
+			if _, ok := grpcData.Request.(map[string]interface{}); !ok {
+				errors = append(errors, fmt.Sprintf("gRPC node '%s' request must be an object", node.ID))
+			}
+		}
+		if grpcData.Timeout != "" {
+			if d, err := time.ParseDuration(grpcData.Timeout); err != nil || d <= 0 || d > models.MaxGrpcTimeout {
+				errors = append(errors, fmt.Sprintf("gRPC node '%s' timeout must be a duration up to %s", node.ID, models.MaxGrpcTimeout))
+			}
+		}
+	case "s3":
+		var s3Data models.S3NodeData
+		if err := DecodeNodeData(node.Data, &s3Data); err != nil {
+			errors = append(errors, fmt.Sprintf("S3 node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateS3(node.ID, &s3Data)...)
+	case "file":
+		var fileData models.FileNodeData
+		if err := DecodeNodeData(node.Data, &fileData); err != nil {
+			errors = append(errors, fmt.Sprintf("File node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		switch fileData.Operation {
+		case models.FileOpRead, models.FileOpWrite, models.FileOpAppend, models.FileOpList, models.FileOpDelete:
+		case models.FileOpMove:
+			if strings.TrimSpace(fileData.Destination) == "" {
+				errors = append(errors, fmt.Sprintf("File node '%s' move requires a destination", node.ID))
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("File node '%s' has invalid operation '%s'", node.ID, fileData.Operation))
+		}
+		if strings.TrimSpace(fileData.File) == "" && fileData.Operation != models.FileOpList {
+			errors = append(errors, fmt.Sprintf("File node '%s' requires a file", node.ID))
+		}
+		if fileData.Output != "" && fileData.Output != "text" && fileData.Output != "file" {
+			errors = append(errors, fmt.Sprintf("File node '%s' output must be 'text' or 'file'", node.ID))
+		}
+		if fileData.Pattern != "" {
+			if _, err := filepath.Match(fileData.Pattern, ""); err != nil {
+				errors = append(errors, fmt.Sprintf("File node '%s' has invalid pattern '%s'", node.ID, fileData.Pattern))
+			}
+		}
+	case "compress":
+		var compressData models.CompressNodeData
+		if err := DecodeNodeData(node.Data, &compressData); err != nil {
+			errors = append(errors, fmt.Sprintf("Compress node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		switch compressData.Operation {
+		case models.CompressOpZip, models.CompressOpUnzip, models.CompressOpGzip,
+			models.CompressOpGunzip, models.CompressOpTar, models.CompressOpUntar:
+		default:
+			errors = append(errors, fmt.Sprintf("Compress node '%s' has invalid operation '%s'", node.ID, compressData.Operation))
+		}
+		if strings.TrimSpace(compressData.Path) == "" {
+			errors = append(errors, fmt.Sprintf("Compress node '%s' requires a path", node.ID))
+		}
+	case "nats":
+		var natsData models.NATSNodeData
+		if err := DecodeNodeData(node.Data, &natsData); err != nil {
+			errors = append(errors, fmt.Sprintf("NATS node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateNATS(node.ID, &natsData)...)
+	case "exec":
+		var execData models.ExecNodeData
+		if err := DecodeNodeData(node.Data, &execData); err != nil {
+			errors = append(errors, fmt.Sprintf("Exec node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		if strings.TrimSpace(execData.Command) == "" {
+			errors = append(errors, fmt.Sprintf("Exec node '%s' requires a command", node.ID))
+		}
+		if execData.Timeout != "" {
+			if d, err := time.ParseDuration(execData.Timeout); err != nil || d <= 0 || d > models.MaxExecTimeout {
+				errors = append(errors, fmt.Sprintf("Exec node '%s' timeout must be a duration up to %s", node.ID, models.MaxExecTimeout))
+			}
+		}
+	case "aggregate":
+		var aggData models.AggregateNodeData
+		if err := DecodeNodeData(node.Data, &aggData); err != nil {
+			errors = append(errors, fmt.Sprintf("Aggregate node '%s' invalid data: %v", node.ID, err))
+			return errors
+		}
+		errors = append(errors, validateAggregate(node.ID, &aggData)...)
 	case "start":
-		// Start nodes typically don't need validation
+		// Start nodes only need validation when they carry a trigger
+		if node.Data != nil {
+			startData, err := ParseStartNodeData(node.Data)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("Start node '%s' invalid data: %v", node.ID, err))
+				return errors
+			}
+			if startData.Trigger != nil {
+				errors = append(errors, validateTrigger(node.ID, startData.Trigger)...)
+			}
+		}
 	case "output":
 		// Output nodes typically don't need validation
 	default:
@@ -310,3 +708,569 @@ func parseCodeNodeData(data interface{}) (*models.CodeNodeData, error) {
 		return nil, fmt.Errorf("unsupported code node data type %T", data)
 	}
 }
+
+// ParseStartNodeData converts raw start node data into its typed form
+func ParseStartNodeData(data interface{}) (*models.StartNodeData, error) {
+	switch v := data.(type) {
+	case models.StartNodeData:
+		return &v, nil
+	case map[string]interface{}:
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var parsed models.StartNodeData
+		if err := json.Unmarshal(bytes, &parsed); err != nil {
+			return nil, err
+		}
+		return &parsed, nil
+	default:
+		return nil, fmt.Errorf("unsupported start node data type %T", data)
+	}
+}
+
+func validateTrigger(nodeID string, trigger *models.TriggerConfig) []string {
+	var errors []string
+
+	switch trigger.Type {
+	case "", models.TriggerTypeManual:
+		// Manual runs need no configuration
+	case models.TriggerTypePostgres:
+		channel := strings.TrimSpace(trigger.Channel)
+		if channel == "" {
+			errors = append(errors, fmt.Sprintf("Start node '%s' postgres trigger requires a channel", nodeID))
+		} else if len(channel) > 63 {
+			// Postgres truncates identifiers longer than NAMEDATALEN-1 bytes
+			errors = append(errors, fmt.Sprintf("Start node '%s' postgres trigger channel is longer than 63 characters", nodeID))
+		}
+	case models.TriggerTypeFileWatch:
+		dir := filepath.Clean(filepath.FromSlash(strings.TrimLeft(trigger.Directory, "/")))
+		if dir != "." && !filepath.IsLocal(dir) {
+			errors = append(errors, fmt.Sprintf("Start node '%s' file_watch directory must stay inside the watch root", nodeID))
+		}
+		if trigger.Pattern != "" {
+			if _, err := filepath.Match(trigger.Pattern, ""); err != nil {
+				errors = append(errors, fmt.Sprintf("Start node '%s' file_watch has invalid pattern '%s'", nodeID, trigger.Pattern))
+			}
+		}
+		if trigger.Debounce != "" {
+			if d, err := time.ParseDuration(trigger.Debounce); err != nil || d < 0 {
+				errors = append(errors, fmt.Sprintf("Start node '%s' file_watch has invalid debounce '%s'", nodeID, trigger.Debounce))
+			}
+		}
+	case models.TriggerTypeEmail:
+		if _, err := mail.ParseAddress(trigger.Address); err != nil {
+			errors = append(errors, fmt.Sprintf("Start node '%s' email trigger requires a valid address", nodeID))
+		}
+	case models.TriggerTypeNATS:
+		if !validNATSSubject(trigger.Subject, true) {
+			errors = append(errors, fmt.Sprintf("Start node '%s' nats trigger requires a valid subject", nodeID))
+		}
+		if strings.ContainsAny(trigger.Queue, " \t\r\n") {
+			errors = append(errors, fmt.Sprintf("Start node '%s' nats trigger queue must not contain whitespace", nodeID))
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("Start node '%s' has unknown trigger type '%s'", nodeID, trigger.Type))
+	}
+
+	return errors
+}
+
+// DecodeNodeData converts raw node data (decoded JSON) into the typed struct out points to
+func DecodeNodeData(data interface{}, out interface{}) error {
+	if data == nil {
+		return nil
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, out)
+}
+
+func isValidSetType(t string) bool {
+	switch t {
+	case "", "string", "number", "boolean", "bool", "object", "array":
+		return true
+	default:
+		return false
+	}
+}
+
+func validateFilter(nodeID string, data *models.FilterNodeData) []string {
+	var errors []string
+
+	if !isValidCombinator(data.Combinator) {
+		errors = append(errors, fmt.Sprintf("Filter node '%s' has invalid combinator '%s'", nodeID, data.Combinator))
+	}
+	if len(data.Groups) == 0 {
+		errors = append(errors, fmt.Sprintf("Filter node '%s' requires at least one condition group", nodeID))
+	}
+
+	for gi, group := range data.Groups {
+		if !isValidCombinator(group.Combinator) {
+			errors = append(errors, fmt.Sprintf("Filter node '%s' group %d has invalid combinator '%s'", nodeID, gi+1, group.Combinator))
+		}
+		for ci, cond := range group.Conditions {
+			where := fmt.Sprintf("Filter node '%s' group %d condition %d", nodeID, gi+1, ci+1)
+			switch cond.Operator {
+			case models.FilterOpEquals, models.FilterOpNotEquals, models.FilterOpContains, models.FilterOpNotContains,
+				models.FilterOpStartsWith, models.FilterOpEndsWith, models.FilterOpIsEmpty, models.FilterOpIsNotEmpty:
+			case models.FilterOpRegex:
+				pattern, ok := cond.Value.(string)
+				if !ok {
+					errors = append(errors, fmt.Sprintf("%s: regex requires a string pattern", where))
+				} else if _, err := regexp.Compile(pattern); err != nil {
+					errors = append(errors, fmt.Sprintf("%s: invalid regex: %v", where, err))
+				}
+			case models.FilterOpGt, models.FilterOpGte, models.FilterOpLt, models.FilterOpLte:
+				if !isNumeric(cond.Value) {
+					errors = append(errors, fmt.Sprintf("%s: operator '%s' requires a numeric value", where, cond.Operator))
+				}
+			case models.FilterOpBefore, models.FilterOpAfter:
+				if !isDateValue(cond.Value) {
+					errors = append(errors, fmt.Sprintf("%s: operator '%s' requires a date value (RFC 3339 or YYYY-MM-DD)", where, cond.Operator))
+				}
+			case models.FilterOpIsType:
+				switch cond.Value {
+				case "string", "number", "boolean", "object", "array", "null":
+				default:
+					errors = append(errors, fmt.Sprintf("%s: is_type requires one of string, number, boolean, object, array, null", where))
+				}
+			default:
+				errors = append(errors, fmt.Sprintf("%s: unknown operator '%s'", where, cond.Operator))
+			}
+		}
+	}
+
+	return errors
+}
+
+func isValidCombinator(c string) bool {
+	switch strings.ToLower(c) {
+	case "", "and", "or":
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumeric(v interface{}) bool {
+	switch val := v.(type) {
+	case float64, int, int64:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func isDateValue(v interface{}) bool {
+	switch val := v.(type) {
+	case float64:
+		return true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+			if _, err := time.Parse(layout, strings.TrimSpace(val)); err == nil {
+				return true
+			}
+		}
+		return isNumeric(val)
+	default:
+		return false
+	}
+}
+
+func validateItemsOperations(nodeID string, ops []models.ItemsOperation) []string {
+	var errors []string
+
+	if len(ops) == 0 {
+		errors = append(errors, fmt.Sprintf("Items node '%s' requires at least one operation", nodeID))
+	}
+	for i, op := range ops {
+		where := fmt.Sprintf("Items node '%s' operation %d", nodeID, i+1)
+		switch op.Type {
+		case models.ItemsOpSort:
+			if len(op.Sort) == 0 {
+				errors = append(errors, fmt.Sprintf("%s: sort requires at least one field", where))
+			}
+			for _, f := range op.Sort {
+				if strings.TrimSpace(f.Field) == "" {
+					errors = append(errors, fmt.Sprintf("%s: sort field requires a name", where))
+				}
+				if !isValidSortDirection(f.Direction) {
+					errors = append(errors, fmt.Sprintf("%s: invalid sort direction '%s'", where, f.Direction))
+				}
+			}
+		case models.ItemsOpLimit:
+			if op.Limit < 0 || op.Offset < 0 {
+				errors = append(errors, fmt.Sprintf("%s: limit and offset must not be negative", where))
+			}
+		case models.ItemsOpFlatten:
+			if op.Depth < 0 {
+				errors = append(errors, fmt.Sprintf("%s: flatten depth must not be negative", where))
+			}
+		case models.ItemsOpDedupe, models.ItemsOpReverse:
+		default:
+			errors = append(errors, fmt.Sprintf("%s: unknown operation '%s'", where, op.Type))
+		}
+	}
+
+	return errors
+}
+
+func isValidSortDirection(d string) bool {
+	switch strings.ToLower(d) {
+	case "", "asc", "desc":
+		return true
+	default:
+		return false
+	}
+}
+
+func validateAggregate(nodeID string, data *models.AggregateNodeData) []string {
+	var errors []string
+
+	if len(data.Aggregations) == 0 {
+		errors = append(errors, fmt.Sprintf("Aggregate node '%s' requires at least one aggregation", nodeID))
+	}
+	for _, field := range data.GroupBy {
+		if strings.TrimSpace(field) == "" {
+			errors = append(errors, fmt.Sprintf("Aggregate node '%s' has an empty group-by field", nodeID))
+		}
+	}
+
+	outputs := make(map[string]bool)
+	for i, agg := range data.Aggregations {
+		where := fmt.Sprintf("Aggregate node '%s' aggregation %d", nodeID, i+1)
+		switch agg.Function {
+		case models.AggCount:
+		case models.AggSum, models.AggAvg, models.AggMin, models.AggMax, models.AggFirst,
+			models.AggLast, models.AggConcat, models.AggDistinct:
+			if strings.TrimSpace(agg.Field) == "" {
+				errors = append(errors, fmt.Sprintf("%s: '%s' requires a field", where, agg.Function))
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("%s: unknown function '%s'", where, agg.Function))
+			continue
+		}
+
+		name := models.AggregationOutputName(agg)
+		if outputs[name] {
+			errors = append(errors, fmt.Sprintf("%s: duplicate output field '%s'", where, name))
+		}
+		outputs[name] = true
+	}
+
+	return errors
+}
+
+func validateDatetime(nodeID string, data *models.DatetimeNodeData) []string {
+	var errors []string
+
+	unit := models.DatetimeUnit(data.Unit)
+	switch data.Operation {
+	case models.DatetimeOpFormat, models.DatetimeOpDiff, models.DatetimeOpBusinessDaysBetween:
+	case models.DatetimeOpConvert:
+		if strings.TrimSpace(data.ToTimezone) == "" {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' convert requires toTimezone", nodeID))
+		}
+	case models.DatetimeOpAdd, models.DatetimeOpSubtract:
+		if unit == "" {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' has unknown unit '%s'", nodeID, data.Unit))
+		}
+	case models.DatetimeOpStartOf, models.DatetimeOpEndOf:
+		if unit == "" || unit == "second" || unit == "millisecond" {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' cannot take the %s of unit '%s'", nodeID, data.Operation, data.Unit))
+		}
+	case models.DatetimeOpAddBusinessDays:
+	default:
+		errors = append(errors, fmt.Sprintf("Datetime node '%s' has unknown operation '%s'", nodeID, data.Operation))
+	}
+	if data.Operation == models.DatetimeOpDiff && data.Unit != "" && unit == "" {
+		errors = append(errors, fmt.Sprintf("Datetime node '%s' has unknown unit '%s'", nodeID, data.Unit))
+	}
+
+	for _, tz := range []string{data.Timezone, data.ToTimezone} {
+		if tz == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(tz); err != nil {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' has invalid timezone '%s'", nodeID, tz))
+		}
+	}
+	if data.WeekStart != "" && data.WeekStart != "monday" && data.WeekStart != "sunday" {
+		errors = append(errors, fmt.Sprintf("Datetime node '%s' weekStart must be 'monday' or 'sunday'", nodeID))
+	}
+	for _, day := range data.Holidays {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			errors = append(errors, fmt.Sprintf("Datetime node '%s' holiday '%s' must be YYYY-MM-DD", nodeID, day))
+		}
+	}
+
+	return errors
+}
+
+func validateCrypto(nodeID string, data *models.CryptoNodeData) []string {
+	var errors []string
+
+	switch data.Operation {
+	case models.CryptoOpHash, models.CryptoOpHMAC:
+		switch data.Algorithm {
+		case "", "md5", "sha1", "sha256", "sha512":
+		default:
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' has unknown algorithm '%s'", nodeID, data.Algorithm))
+		}
+	case models.CryptoOpEncode, models.CryptoOpDecode, models.CryptoOpEncrypt, models.CryptoOpDecrypt:
+	case models.CryptoOpUUID:
+		if data.Version != 0 && data.Version != 4 && data.Version != 7 {
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' uuid version must be 4 or 7", nodeID))
+		}
+	case models.CryptoOpRandom:
+		if data.Length < 0 || data.Length > 4096 {
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' random length must be between 1 and 4096", nodeID))
+		}
+		switch data.Charset {
+		case "", "alphanumeric", "numeric", "hex", "base64url":
+		default:
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' has unknown charset '%s'", nodeID, data.Charset))
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("Crypto node '%s' has unknown operation '%s'", nodeID, data.Operation))
+		return errors
+	}
+
+	switch data.Operation {
+	case models.CryptoOpHMAC, models.CryptoOpEncrypt, models.CryptoOpDecrypt:
+		if strings.TrimSpace(data.Credential) == "" {
+			errors = append(errors, fmt.Sprintf("Crypto node '%s' %s requires a credential", nodeID, data.Operation))
+		}
+	}
+	switch data.Encoding {
+	case "", "hex", "base64", "base64url":
+	default:
+		errors = append(errors, fmt.Sprintf("Crypto node '%s' encoding must be 'hex', 'base64' or 'base64url'", nodeID))
+	}
+
+	return errors
+}
+
+func validateJWT(nodeID string, data *models.JWTNodeData) []string {
+	var errors []string
+
+	switch data.Algorithm {
+	case "", "HS256", "RS256", "ES256":
+	default:
+		errors = append(errors, fmt.Sprintf("JWT node '%s' algorithm must be HS256, RS256 or ES256", nodeID))
+	}
+
+	switch data.Operation {
+	case "sign":
+		if data.Algorithm == "" {
+			errors = append(errors, fmt.Sprintf("JWT node '%s' sign requires an algorithm", nodeID))
+		}
+		if strings.TrimSpace(data.Credential) == "" {
+			errors = append(errors, fmt.Sprintf("JWT node '%s' sign requires a credential", nodeID))
+		}
+		if data.ExpiresIn != "" {
+			if d, err := time.ParseDuration(data.ExpiresIn); err != nil || d <= 0 {
+				errors = append(errors, fmt.Sprintf("JWT node '%s' has invalid expiresIn '%s'", nodeID, data.ExpiresIn))
+			}
+		}
+	case "verify":
+		if strings.TrimSpace(data.Token) == "" {
+			errors = append(errors, fmt.Sprintf("JWT node '%s' verify requires a token", nodeID))
+		}
+		hasCredential := strings.TrimSpace(data.Credential) != ""
+		hasJWKS := strings.TrimSpace(data.JWKSURL) != ""
+		if hasCredential == hasJWKS {
+			errors = append(errors, fmt.Sprintf("JWT node '%s' verify requires either a credential or a jwksUrl", nodeID))
+		}
+		if hasJWKS {
+			if u, err := url.Parse(data.JWKSURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				errors = append(errors, fmt.Sprintf("JWT node '%s' has invalid jwksUrl '%s'", nodeID, data.JWKSURL))
+			}
+		}
+		if data.Leeway != "" {
+			if d, err := time.ParseDuration(data.Leeway); err != nil || d < 0 {
+				errors = append(errors, fmt.Sprintf("JWT node '%s' has invalid leeway '%s'", nodeID, data.Leeway))
+			}
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("JWT node '%s' operation must be 'sign' or 'verify'", nodeID))
+	}
+
+	return errors
+}
+
+func validateS3(nodeID string, data *models.S3NodeData) []string {
+	var errors []string
+
+	if strings.TrimSpace(data.Credential) == "" {
+		errors = append(errors, fmt.Sprintf("S3 node '%s' requires a credential", nodeID))
+	}
+	if strings.TrimSpace(data.Bucket) == "" {
+		errors = append(errors, fmt.Sprintf("S3 node '%s' requires a bucket", nodeID))
+	}
+
+	switch data.Operation {
+	case models.S3OpPut, models.S3OpGet, models.S3OpDelete:
+	case models.S3OpList:
+		if data.MaxKeys < 0 {
+			errors = append(errors, fmt.Sprintf("S3 node '%s' maxKeys cannot be negative", nodeID))
+		}
+	case models.S3OpPresign:
+		if data.PresignMethod != "" && data.PresignMethod != "GET" && data.PresignMethod != "PUT" {
+			errors = append(errors, fmt.Sprintf("S3 node '%s' presignMethod must be 'GET' or 'PUT'", nodeID))
+		}
+		if data.Expires != "" {
+			if d, err := time.ParseDuration(data.Expires); err != nil || d < time.Second || d > 7*24*time.Hour {
+				errors = append(errors, fmt.Sprintf("S3 node '%s' expires must be between 1s and 168h", nodeID))
+			}
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("S3 node '%s' has invalid operation '%s'", nodeID, data.Operation))
+		return errors
+	}
+	if data.Operation != models.S3OpList && strings.TrimSpace(data.Key) == "" {
+		errors = append(errors, fmt.Sprintf("S3 node '%s' %s requires a key", nodeID, data.Operation))
+	}
+
+	return errors
+}
+
+func validateNATS(nodeID string, data *models.NATSNodeData) []string {
+	var errors []string
+
+	if data.Operation != models.NATSOpPublish && data.Operation != models.NATSOpRequest {
+		errors = append(errors, fmt.Sprintf("NATS node '%s' has invalid operation '%s'", nodeID, data.Operation))
+	}
+	if strings.TrimSpace(data.Credential) == "" {
+		errors = append(errors, fmt.Sprintf("NATS node '%s' requires a credential", nodeID))
+	}
+	// Templated subjects are only known at run time
+	if !strings.Contains(data.Subject, "{{") && !validNATSSubject(data.Subject, false) {
+		errors = append(errors, fmt.Sprintf("NATS node '%s' requires a valid subject without wildcards", nodeID))
+	}
+	if data.Timeout != "" {
+		if d, err := time.ParseDuration(data.Timeout); err != nil || d <= 0 || d > models.MaxNATSRequestTimeout {
+			errors = append(errors, fmt.Sprintf("NATS node '%s' timeout must be a duration up to %s", nodeID, models.MaxNATSRequestTimeout))
+		}
+	}
+
+	return errors
+}
+
+// validNATSSubject reports whether s is a dot-separated NATS subject. With
+// wildcards, a token may be * and the last token may be >.
+func validNATSSubject(s string, wildcards bool) bool {
+	if s == "" || strings.ContainsAny(s, " \t\r\n") {
+		return false
+	}
+	tokens := strings.Split(s, ".")
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return false
+		case token == "*" || (token == ">" && i == len(tokens)-1):
+			if !wildcards {
+				return false
+			}
+		case strings.ContainsAny(token, "*>"):
+			return false
+		}
+	}
+	return true
+}
+
+func validateRedis(nodeID string, data *models.RedisNodeData) []string {
+	var errors []string
+
+	if strings.TrimSpace(data.Credential) == "" {
+		errors = append(errors, fmt.Sprintf("Redis node '%s' requires a credential", nodeID))
+	}
+
+	needsField := false
+	needsValue := false
+	switch data.Operation {
+	case models.RedisOpGet, models.RedisOpDelete, models.RedisOpIncr, models.RedisOpHGetAll, models.RedisOpRPop:
+	case models.RedisOpSet, models.RedisOpLPush:
+		needsValue = true
+	case models.RedisOpHGet, models.RedisOpHDel:
+		needsField = true
+	case models.RedisOpHSet:
+		needsField = true
+		needsValue = true
+	case models.RedisOpPublish:
+		if strings.TrimSpace(data.Channel) == "" {
+			errors = append(errors, fmt.Sprintf("Redis node '%s' publish requires a channel", nodeID))
+		}
+		if data.Value == nil {
+			errors = append(errors, fmt.Sprintf("Redis node '%s' publish requires a value", nodeID))
+		}
+		return errors
+	default:
+		errors = append(errors, fmt.Sprintf("Redis node '%s' has invalid operation '%s'", nodeID, data.Operation))
+		return errors
+	}
+
+	if strings.TrimSpace(data.Key) == "" {
+		errors = append(errors, fmt.Sprintf("Redis node '%s' %s requires a key", nodeID, data.Operation))
+	}
+	if needsField && strings.TrimSpace(data.Field) == "" {
+		errors = append(errors, fmt.Sprintf("Redis node '%s' %s requires a field", nodeID, data.Operation))
+	}
+	if needsValue && data.Value == nil {
+		errors = append(errors, fmt.Sprintf("Redis node '%s' %s requires a value", nodeID, data.Operation))
+	}
+	if data.TTL != "" {
+		if d, err := time.ParseDuration(data.TTL); err != nil || d <= 0 {
+			errors = append(errors, fmt.Sprintf("Redis node '%s' has invalid ttl '%s'", nodeID, data.TTL))
+		}
+	}
+
+	return errors
+}
+
+func validateParse(nodeID string, data *models.ParseNodeData) []string {
+	var errors []string
+
+	if data.Format != "xml" && data.Format != "html" {
+		errors = append(errors, fmt.Sprintf("Parse node '%s' format must be 'xml' or 'html'", nodeID))
+	}
+	if data.Format == "html" && len(data.Fields) == 0 {
+		errors = append(errors, fmt.Sprintf("Parse node '%s' requires fields to extract from html", nodeID))
+	}
+
+	names := make(map[string]bool)
+	for i, field := range data.Fields {
+		where := fmt.Sprintf("Parse node '%s' field %d", nodeID, i+1)
+		if strings.TrimSpace(field.Name) == "" {
+			errors = append(errors, fmt.Sprintf("%s requires a name", where))
+		} else if names[field.Name] {
+			errors = append(errors, fmt.Sprintf("%s: duplicate name '%s'", where, field.Name))
+		}
+		names[field.Name] = true
+
+		switch {
+		case field.CSS != "" && field.XPath != "":
+			errors = append(errors, fmt.Sprintf("%s: set either css or xpath, not both", where))
+		case field.CSS != "":
+			if data.Format == "xml" {
+				errors = append(errors, fmt.Sprintf("%s: css selectors only apply to html", where))
+			} else if _, err := cascadia.Compile(field.CSS); err != nil {
+				errors = append(errors, fmt.Sprintf("%s: invalid css selector: %v", where, err))
+			}
+		case field.XPath != "":
+			if _, err := xpath.Compile(field.XPath); err != nil {
+				errors = append(errors, fmt.Sprintf("%s: invalid xpath: %v", where, err))
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("%s requires a css selector or xpath", where))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,58 @@
+package dag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/your-org/n8n-clone/internal/db/models"
+)
+
+func TestValidateDAGSourceHandles(t *testing.T) {
+	build := func(filterData map[string]interface{}, handle string) *models.DAGStructure {
+		return &models.DAGStructure{
+			Nodes: []models.Node{
+				{ID: "start", Type: "start"},
+				{ID: "filter", Type: "filter", Data: filterData},
+				{ID: "out", Type: "output"},
+			},
+			Edges: []models.Edge{
+				{ID: "e1", Source: "start", Target: "filter"},
+				{ID: "e2", Source: "filter", Target: "out", SourceHandle: handle},
+			},
+		}
+	}
+	groups := []interface{}{map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"field": "status", "operator": "equals", "value": "paid"}},
+	}}
+	conditions := map[string]interface{}{"groups": groups}
+	withDiscarded := map[string]interface{}{"groups": groups, "emitDiscarded": true}
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		handle  string
+		wantErr bool
+	}{
+		{name: "default output", data: conditions, handle: ""},
+		{name: "kept", data: conditions, handle: "kept"},
+		{name: "discarded when emitted", data: withDiscarded, handle: "discarded"},
+		{name: "discarded when not emitted", data: conditions, handle: "discarded", wantErr: true},
+		{name: "misspelled", data: withDiscarded, handle: "discard", wantErr: true},
+	}
+	for _, tt := range tests {
+		result := ValidateDAG(build(tt.data, tt.handle))
+		if !tt.wantErr && !result.Valid {
+			t.Errorf("%s: unexpected errors %v", tt.name, result.Errors)
+			continue
+		}
+		hasErr := false
+		for _, err := range result.Errors {
+			if strings.Contains(err, "unknown output") {
+				hasErr = true
+			}
+		}
+		if hasErr != tt.wantErr {
+			t.Errorf("%s: errors = %v, want handle error %v", tt.name, result.Errors, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !unix
+
+package fsutil
+
+// ONoFollow is not available on this platform
+const ONoFollow = 0
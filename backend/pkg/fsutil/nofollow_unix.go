@@ -0,0 +1,9 @@
+//go:build unix
+
+package fsutil
+
+import "syscall"
+
+// ONoFollow makes os.OpenFile fail when the final path component is a
+// symlink, closing the gap between ResolvePath and the open
+const ONoFollow = syscall.O_NOFOLLOW
@@ -0,0 +1,71 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoot is returned when a path escapes the sandbox root
+var ErrOutsideRoot = errors.New("path escapes sandbox root")
+
+// ResolvePath joins a user supplied path onto root and guarantees the result
+// stays inside root, including after following symlinks. Leading slashes are
+// treated as relative to root.
+func ResolvePath(root, p string) (string, error) {
+	if root == "" {
+		return "", errors.New("sandbox root is not configured")
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(absRoot); err == nil {
+		absRoot = resolved
+	}
+
+	rel := filepath.Clean(strings.TrimLeft(filepath.FromSlash(p), string(filepath.Separator)))
+	if rel != "." && !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, p)
+	}
+	full := filepath.Join(absRoot, rel)
+
+	// Resolve symlinks on the longest existing prefix so targets that do not
+	// exist yet (writes) are still checked
+	existing := full
+	var rest []string
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		rest = append([]string{filepath.Base(existing)}, rest...)
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		// A dangling symlink cannot be checked, and writing through it would
+		// create its target wherever it points
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, p)
+	}
+	full = filepath.Join(append([]string{resolved}, rest...)...)
+
+	if !Within(absRoot, full) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, p)
+	}
+	return full, nil
+}
+
+// Within reports whether path is root or lies beneath it
+func Within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || filepath.IsLocal(rel)
+}
@@ -0,0 +1,70 @@
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"escape":   outside,                                // directory outside the root
+		"dangling": filepath.Join(outside, "pwned.txt"),    // file outside the root that does not exist yet
+		"inside":   filepath.Join(root, "data"),            // directory inside the root
+		"broken":   filepath.Join(root, "data", "missing"), // dangling even though it points inside
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		escapes bool
+	}{
+		{path: "", want: realRoot},
+		{path: "/data/report.txt", want: filepath.Join(realRoot, "data", "report.txt")},
+		{path: "data/new/report.txt", want: filepath.Join(realRoot, "data", "new", "report.txt")},
+		{path: "inside/report.txt", want: filepath.Join(realRoot, "data", "report.txt")},
+		{path: "../report.txt", escapes: true},
+		{path: "data/../../report.txt", escapes: true},
+		{path: "escape/report.txt", escapes: true},
+		{path: "dangling", escapes: true},
+		{path: "broken", escapes: true},
+		{path: "broken/report.txt", escapes: true},
+	}
+	for _, tt := range tests {
+		got, err := ResolvePath(root, tt.path)
+		if tt.escapes {
+			if !errors.Is(err, ErrOutsideRoot) {
+				t.Errorf("ResolvePath(%q) = %q, %v; want ErrOutsideRoot", tt.path, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ResolvePath(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(outside, "pwned.txt")); !os.IsNotExist(err) {
+		t.Errorf("file outside the root was created: %v", err)
+	}
+}
+
+func TestResolvePathRequiresRoot(t *testing.T) {
+	if _, err := ResolvePath("", "report.txt"); err == nil {
+		t.Error("ResolvePath with an empty root succeeded")
+	}
+}
@@ -0,0 +1,107 @@
+package protoset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Parse decodes a serialized FileDescriptorSet, as written by
+// `protoc --descriptor_set_out --include_imports`, and links its files
+func Parse(data []byte) (*protoregistry.Files, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %w", err)
+	}
+	if len(set.File) == 0 {
+		return nil, fmt.Errorf("descriptor set holds no files")
+	}
+	return Build(set.File)
+}
+
+// Build links file descriptors into a registry. Imports missing from fds are
+// taken from the well-known types compiled into the binary.
+func Build(fds []*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	fds = append([]*descriptorpb.FileDescriptorProto(nil), fds...)
+	byName := make(map[string]*descriptorpb.FileDescriptorProto, len(fds))
+	for _, fd := range fds {
+		byName[fd.GetName()] = fd
+	}
+	for i := 0; i < len(fds); i++ {
+		for _, dep := range fds[i].GetDependency() {
+			if _, ok := byName[dep]; ok {
+				continue
+			}
+			known, err := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if err != nil {
+				return nil, fmt.Errorf("%s imports %s, which is not in the descriptor set", fds[i].GetName(), dep)
+			}
+			byName[dep] = protodesc.ToFileDescriptorProto(known)
+			fds = append(fds, byName[dep])
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: make([]*descriptorpb.FileDescriptorProto, 0, len(byName))}
+	for _, fd := range byName {
+		set.File = append(set.File, fd)
+	}
+	return protodesc.NewFiles(set)
+}
+
+// Services lists the fully-qualified names of the services in files
+func Services(files *protoregistry.Files) []string {
+	var names []string
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			names = append(names, string(fd.Services().Get(i).FullName()))
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// FindMethod looks up a method given as "pkg.Service/Method",
+// "/pkg.Service/Method" or "pkg.Service.Method"
+func FindMethod(files *protoregistry.Files, name string) (protoreflect.MethodDescriptor, error) {
+	service, method, ok := SplitMethod(name)
+	if !ok {
+		return nil, fmt.Errorf("invalid method name %q", name)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", service, method)
+	}
+	return md, nil
+}
+
+// SplitMethod separates a method name into its service and method parts
+func SplitMethod(name string) (service, method string, ok bool) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		i = strings.LastIndex(name, ".")
+	}
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	service, method = name[:i], name[i+1:]
+	if !protoreflect.FullName(service).IsValid() || !protoreflect.Name(method).IsValid() {
+		return "", "", false
+	}
+	return service, method, true
+}